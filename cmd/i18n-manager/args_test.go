@@ -1,8 +1,12 @@
 package main
 
 import (
+	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/mlechner911/i18ntool/internal/app"
+	"github.com/mlechner911/i18ntool/internal/simpletrans"
 )
 
 func TestBuildFilesMapFromPaths_Detections(t *testing.T) {
@@ -14,7 +18,7 @@ func TestBuildFilesMapFromPaths_Detections(t *testing.T) {
 		"other/en.json", // different path but same basename to force unique suffix
 	}
 
-	files := buildFilesMapFromPaths(paths)
+	files := buildFilesMapFromPaths(paths, &app.Config{})
 
 	// Expect keys: en, de, customfile, noext, en-1
 	wantKeys := []string{"en", "de", "customfile", "noext", "en-1"}
@@ -41,3 +45,132 @@ func TestBuildFilesMapFromPaths_Detections(t *testing.T) {
 		t.Fatalf("de should map to locales/de.json, got %s", files["de"])
 	}
 }
+
+func TestTakeFlags(t *testing.T) {
+	args := []string{"a.json", "--keep", "x.*", "b.json", "--keep=y", "--dry-run", "--source", "en"}
+
+	keep, rest := takeFlags(args, "--keep")
+	if !reflect.DeepEqual(keep, []string{"x.*", "y"}) {
+		t.Fatalf("unexpected --keep values: %v", keep)
+	}
+	dry, rest := takeBoolFlag(rest, "--dry-run")
+	source, rest := takeFlag(rest, "--source")
+	if !dry || source != "en" {
+		t.Fatalf("expected dry-run and source en, got %v %q", dry, source)
+	}
+	if !reflect.DeepEqual(rest, []string{"a.json", "b.json"}) {
+		t.Fatalf("unexpected remaining args: %v", rest)
+	}
+}
+
+func TestSplitAddAllArgs(t *testing.T) {
+	files, key, values, ok := splitAddAllArgs([]string{"en.json", "locales/de.JSON", "menu.save", "en=Save", "de=Speichern", "fr="})
+	if !ok {
+		t.Fatalf("expected arguments to be accepted")
+	}
+	if !reflect.DeepEqual(files, []string{"en.json", "locales/de.JSON"}) || key != "menu.save" {
+		t.Fatalf("unexpected files %v / key %q", files, key)
+	}
+	if want := map[string]string{"en": "Save", "de": "Speichern", "fr": ""}; !reflect.DeepEqual(values, want) {
+		t.Fatalf("want %v, got %v", want, values)
+	}
+
+	for _, bad := range [][]string{{"menu.save", "en=x"}, {"en.json"}, {"en.json", "key", "Save"}} {
+		if _, _, _, ok := splitAddAllArgs(bad); ok {
+			t.Errorf("expected %v to be rejected", bad)
+		}
+	}
+}
+
+func TestTakeScanConfigUsesProjectConfig(t *testing.T) {
+	extract := app.ExtractConfig{Presets: []string{"go"}, Skip: []string{"build"}}
+
+	cfg, rest, err := takeScanConfig([]string{"en.json", "--skip", "tmp"}, extract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Extractors) != 1 || cfg.Extractors[0].Name != "go" {
+		t.Fatalf("expected the configured go preset, got %+v", cfg.Extractors)
+	}
+	if want := append(append([]string(nil), app.DefaultSkipDirs...), "build", "tmp"); !reflect.DeepEqual(cfg.SkipDirs, want) {
+		t.Fatalf("SkipDirs = %v, want %v", cfg.SkipDirs, want)
+	}
+	if !reflect.DeepEqual(rest, []string{"en.json"}) {
+		t.Fatalf("unexpected remaining args: %v", rest)
+	}
+
+	cfg, _, err = takeScanConfig([]string{"--preset", "vue-i18n"}, extract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Extractors) != 1 || cfg.Extractors[0].Name != "vue-i18n" {
+		t.Fatalf("expected --preset to replace the configured presets, got %+v", cfg.Extractors)
+	}
+}
+
+func TestFilterPlurals(t *testing.T) {
+	cfg := &app.Config{Check: app.CheckConfig{Disable: []string{app.RulePluralUnused}}}
+	plurals := []app.PluralIssue{
+		{Key: "item", Language: "en", Missing: []string{"one"}, Unused: []string{"few"}},
+		{Key: "file", Language: "de", Unused: []string{"many"}},
+	}
+	got := filterPlurals(cfg, plurals)
+	if len(got) != 1 || got[0].Key != "item" || got[0].Unused != nil {
+		t.Fatalf("unexpected filtered plurals: %+v", got)
+	}
+}
+
+func TestTakeScanConfigRejectsGoFuncWithoutGoPreset(t *testing.T) {
+	if _, _, err := takeScanConfig([]string{"--preset", "vue-i18n", "--go-func", "Tr:1"}, app.ExtractConfig{}); err == nil {
+		t.Fatalf("expected an error for --go-func without a Go extractor")
+	}
+	if _, _, err := takeScanConfig(nil, app.ExtractConfig{Presets: []string{"i18next"}, GoFuncs: []string{"Tr:1"}}); err == nil {
+		t.Fatalf("expected an error for configured go_funcs without a Go extractor")
+	}
+	if _, _, err := takeScanConfig([]string{"--preset", "vue-i18n", "--preset", "go", "--go-func", "Tr:1"}, app.ExtractConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestConfigScopeFor(t *testing.T) {
+	tests := []struct {
+		args []string
+		want configScope
+	}{
+		{[]string{"help"}, configNone},
+		{[]string{"simple", "en.json", "key"}, configNone},
+		{[]string{"embed", "--out", "x.go", "--fs", "locales/*.json"}, configNone},
+		{[]string{"embed", "--out", "x.go"}, configNearest},
+		{[]string{"check", "a.json", "b.json"}, configHere},
+		{[]string{"check", "--source", "en"}, configNearest},
+		{[]string{"rename", "old.key", "new.key"}, configNearest},
+	}
+	for _, tt := range tests {
+		if got := configScopeFor(tt.args[0], tt.args[1:]); got != tt.want {
+			t.Errorf("configScopeFor(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestWriteGeneratedCreatesDirectories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gen", "keys_gen.go")
+	if written, err := writeGenerated(path, []byte("package gen\n")); err != nil || !written {
+		t.Fatalf("writeGenerated: %v, %v", written, err)
+	}
+	if written, err := writeGenerated(path, []byte("package gen\n")); err != nil || written {
+		t.Fatalf("unchanged content was written again: %v, %v", written, err)
+	}
+}
+
+func TestCommandUsageKeysExist(t *testing.T) {
+	for command, keys := range commandUsage {
+		for _, key := range keys {
+			if _, ok := simpletrans.EmbeddedTranslations["en"][key]; !ok {
+				t.Errorf("%s: usage message %s is not translated", command, key)
+			}
+		}
+	}
+	if !helpRequested([]string{"a.json", "--help"}) || helpRequested([]string{"a.json"}) {
+		t.Error("helpRequested does not detect --help")
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/mlechner911/i18ntool/internal/app"
+)
+
+// configScope tells where a command looks for the project configuration.
+type configScope int
+
+const (
+	configNone    configScope = iota // the configuration is not read
+	configHere                       // --config, or a file in the working directory
+	configNearest                    // --config, or the nearest file in the working directory or its parents
+)
+
+// loadProjectConfig loads the configuration file given with --config, or the
+// one scope finds. Without one it returns an empty configuration.
+func loadProjectConfig(path string, scope configScope) (*app.Config, error) {
+	if path == "" {
+		find := app.FindConfig
+		if scope == configHere {
+			find = app.ConfigIn
+		}
+		found, err := find(".")
+		if err != nil || found == "" {
+			return &app.Config{}, err
+		}
+		path = found
+	}
+	return app.LoadConfig(path)
+}
+
+// configScopeFor tells how command reads the project configuration. help and
+// simple never do, and embed not when locale files are given, so a broken
+// configuration above the package does not break go generate. Other commands
+// given locale files (*.json) skip the parent directories, so an unrelated
+// configuration further up does not break them either.
+func configScopeFor(command string, args []string) configScope {
+	switch command {
+	case "help", "simple":
+		return configNone
+	case "embed":
+		_, rest := takeFlag(args, "--out")
+		_, rest = takeFlag(rest, "--package")
+		_, rest = takeFlag(rest, "--var")
+		_, rest = takeBoolFlag(rest, "--fs")
+		if len(rest) > 0 {
+			return configNone
+		}
+	}
+	for _, a := range args {
+		if !strings.HasPrefix(a, "-") && strings.EqualFold(filepath.Ext(a), ".json") {
+			return configHere
+		}
+	}
+	return configNearest
+}
+
+// localeFiles returns paths, or the configured locale files when no paths
+// were given on the command line.
+func localeFiles(cfg *app.Config, paths []string) ([]string, error) {
+	if len(paths) > 0 {
+		return paths, nil
+	}
+	return cfg.LocaleFiles()
+}
+
+// filterStatuses drops untranslated and orphaned keys when their rule is disabled.
+func filterStatuses(cfg *app.Config, statuses []app.LanguageStatus) []app.LanguageStatus {
+	for i := range statuses {
+		if !cfg.RuleEnabled(app.RuleUntranslated) {
+			statuses[i].Untranslated = nil
+		}
+		if !cfg.RuleEnabled(app.RuleOrphaned) {
+			statuses[i].Orphaned = nil
+		}
+	}
+	return statuses
+}
+
+// filterConflicts drops structure conflicts whose rule is disabled.
+func filterConflicts(cfg *app.Config, conflicts []app.StructureConflict) []app.StructureConflict {
+	out := conflicts[:0]
+	for _, c := range conflicts {
+		if cfg.RuleEnabled(c.Kind) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// filterPlurals drops missing or unused plural forms whose rule is disabled.
+func filterPlurals(cfg *app.Config, plurals []app.PluralIssue) []app.PluralIssue {
+	out := plurals[:0]
+	for _, p := range plurals {
+		if !cfg.RuleEnabled(app.RulePluralMissing) {
+			p.Missing = nil
+		}
+		if !cfg.RuleEnabled(app.RulePluralRare) {
+			p.Rare = nil
+		}
+		if !cfg.RuleEnabled(app.RulePluralUnused) {
+			p.Unused = nil
+		}
+		if !cfg.RuleEnabled(app.RulePluralNoRules) {
+			p.NoRules = false
+		}
+		if len(p.Missing) > 0 || len(p.Rare) > 0 || len(p.Unused) > 0 || p.NoRules {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// filterMessageFormat drops MessageFormat issues whose rule is disabled.
+func filterMessageFormat(cfg *app.Config, issues []app.MessageFormatIssue) []app.MessageFormatIssue {
+	out := issues[:0]
+	for _, m := range issues {
+		if cfg.RuleEnabled(m.Kind) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// filterIssues drops report issues whose rule is disabled.
+func filterIssues(cfg *app.Config, issues []app.Issue) []app.Issue {
+	out := issues[:0]
+	for _, issue := range issues {
+		if cfg.RuleEnabled(issue.Rule) {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
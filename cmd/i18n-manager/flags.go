@@ -0,0 +1,104 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// takeFlags removes every "--name value" and "--name=value" pair from args and
+// returns the collected values (in order) and the remaining arguments.
+func takeFlags(args []string, name string) ([]string, []string) {
+	var values []string
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == name && i+1 < len(args) {
+			values = append(values, args[i+1])
+			i++
+			continue
+		}
+		if strings.HasPrefix(a, name+"=") {
+			values = append(values, strings.TrimPrefix(a, name+"="))
+			continue
+		}
+		rest = append(rest, a)
+	}
+	return values, rest
+}
+
+// takeFlag is takeFlags for options given at most once; the last value wins.
+func takeFlag(args []string, name string) (string, []string) {
+	values, rest := takeFlags(args, name)
+	if len(values) == 0 {
+		return "", rest
+	}
+	return values[len(values)-1], rest
+}
+
+// takeBoolFlag removes a switch such as "--dry-run" from args and reports whether it was present.
+func takeBoolFlag(args []string, name string) (bool, []string) {
+	found := false
+	rest := make([]string, 0, len(args))
+	for _, a := range args {
+		if a == name {
+			found = true
+			continue
+		}
+		rest = append(rest, a)
+	}
+	return found, rest
+}
+
+// commandUsage lists the usage messages "<command> --help" prints.
+var commandUsage = map[string][]string{
+	"check":        {"usage.check"},
+	"lint":         {"usage.lint"},
+	"sort":         {"usage.sort"},
+	"unused":       {"usage.unused"},
+	"undefined":    {"usage.undefined"},
+	"prune":        {"usage.prune"},
+	"rename":       {"usage.rename"},
+	"delete":       {"usage.delete"},
+	"copy":         {"usage.copy"},
+	"add":          {"usage.add", "usage.add_all"},
+	"simple":       {"usage.simple"},
+	"convert":      {"usage.convert"},
+	"export-xliff": {"usage.export_xliff"},
+	"import-xliff": {"usage.import_xliff"},
+	"embed":        {"usage.embed"},
+	"gen-go":       {"usage.gen_go"},
+	"gen-ts":       {"usage.gen_ts"},
+}
+
+// helpRequested reports whether args contain --help or -h, which are never
+// file names.
+func helpRequested(args []string) bool {
+	for _, a := range args {
+		if a == "--help" || a == "-h" {
+			return true
+		}
+	}
+	return false
+}
+
+// splitAddAllArgs splits the arguments of "add --all" into the locale files
+// (every leading .json argument), the key and the lang=value pairs.
+func splitAddAllArgs(args []string) ([]string, string, map[string]string, bool) {
+	i := 0
+	for i < len(args) && strings.EqualFold(filepath.Ext(args[i]), ".json") {
+		i++
+	}
+	if i == 0 || i == len(args) {
+		return nil, "", nil, false
+	}
+	files, key := args[:i], args[i]
+	values := make(map[string]string)
+	for _, pair := range args[i+1:] {
+		lang, value, ok := strings.Cut(pair, "=")
+		if !ok || lang == "" {
+			return nil, "", nil, false
+		}
+		values[lang] = value
+	}
+	return files, key, values, true
+}
@@ -1,10 +1,11 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/mlechner911/i18ntool/internal/app"
@@ -13,8 +14,10 @@ import (
 
 // main is the CLI entrypoint for i18n-manager.
 func main() {
-	// parse optional global flags first (simple scan for --lang or -l)
+	// parse optional global flags first (simple scan for --lang/-l, --format and --config)
 	lang := "en" // default language when not specified
+	format := ""
+	configPath := ""
 	args := make([]string, 0, len(os.Args))
 	args = append(args, os.Args[0])
 	skipNext := false
@@ -31,6 +34,28 @@ func main() {
 				continue
 			}
 		}
+		if a == "--format" {
+			if i+1 < len(os.Args) {
+				format = os.Args[i+1]
+				skipNext = true
+				continue
+			}
+		}
+		if strings.HasPrefix(a, "--format=") {
+			format = strings.TrimPrefix(a, "--format=")
+			continue
+		}
+		if a == "--config" {
+			if i+1 < len(os.Args) {
+				configPath = os.Args[i+1]
+				skipNext = true
+				continue
+			}
+		}
+		if strings.HasPrefix(a, "--config=") {
+			configPath = strings.TrimPrefix(a, "--config=")
+			continue
+		}
 		args = append(args, a)
 	}
 
@@ -76,6 +101,88 @@ func main() {
 	tprintln := func(s string) {
 		fmt.Println(translate(s))
 	}
+	// printConflicts lists structural conflicts with the type and JSON path of the key in each file
+	printConflicts := func(conflicts []app.StructureConflict) {
+		if len(conflicts) == 0 {
+			return
+		}
+		fmt.Println()
+		tprintf(translate("check.structure_count"), len(conflicts))
+		for _, c := range conflicts {
+			tprintf(translate("check.structure_item"), c.Key, c.Kind)
+			for _, loc := range c.Locations {
+				tprintf(translate("check.structure_location"), loc.File, loc.Type, loc.Path)
+			}
+		}
+	}
+
+	// printPlurals lists plural keys whose forms do not match the CLDR categories of a
+	// language, and languages without embedded plural rules
+	printPlurals := func(plurals []app.PluralIssue) {
+		var wrong []app.PluralIssue
+		for _, p := range plurals {
+			if p.NoRules {
+				fmt.Println()
+				tprintf(translate("check.plural_no_rules"), p.Language)
+				continue
+			}
+			wrong = append(wrong, p)
+		}
+		if len(wrong) == 0 {
+			return
+		}
+		fmt.Println()
+		tprintf(translate("check.plural_count"), len(wrong))
+		for _, p := range wrong {
+			if len(p.Missing) > 0 {
+				tprintf(translate("check.plural_missing"), p.Key, p.Language, strings.Join(p.Missing, ", "))
+			}
+			if len(p.Rare) > 0 {
+				tprintf(translate("check.plural_rare"), p.Key, p.Language, strings.Join(p.Rare, ", "))
+			}
+			if len(p.Unused) > 0 {
+				tprintf(translate("check.plural_unused"), p.Key, p.Language, strings.Join(p.Unused, ", "))
+			}
+		}
+	}
+	// pluralsFailed reports whether a language lacks a required plural form;
+	// rare forms and forms a language never uses only warn
+	pluralsFailed := func(plurals []app.PluralIssue) bool {
+		for _, p := range plurals {
+			if len(p.Missing) > 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	// printMessageFormat lists ICU MessageFormat syntax errors and argument mismatches
+	printMessageFormat := func(issues []app.MessageFormatIssue) {
+		if len(issues) == 0 {
+			return
+		}
+		fmt.Println()
+		tprintf(translate("check.icu_count"), len(issues))
+		for _, m := range issues {
+			if m.Kind == app.RuleICUSyntax {
+				tprintf(translate("check.icu_syntax"), m.Key, m.Language, m.Error)
+				continue
+			}
+			tprintf(translate("check.icu_arguments"), m.Key, m.Language, strings.Join(m.Expected, " "), strings.Join(m.Found, " "))
+		}
+	}
+
+	// printDuplicates lists duplicate keys that the JSON decoder collapsed on load
+	printDuplicates := func(duplicates []app.Issue) {
+		if len(duplicates) == 0 {
+			return
+		}
+		fmt.Println()
+		tprintf(translate("check.duplicate_count"), len(duplicates))
+		for _, d := range duplicates {
+			tprintf(translate("lint.item"), d.File, d.Line, d.Column, d.Message)
+		}
+	}
 
 	if len(args) < 2 {
 		tprintln(translate("usage.general"))
@@ -83,74 +190,234 @@ func main() {
 	}
 
 	command := args[1]
+	if usage, ok := commandUsage[command]; ok && helpRequested(args[2:]) {
+		for _, key := range usage {
+			tprintln(translate(key))
+		}
+		os.Exit(0)
+	}
+
+	// the project configuration supplies defaults for locale files and options;
+	// commands that do not use it run even when it is broken
+	cfg := &app.Config{}
+	if scope := configScopeFor(command, args[2:]); scope != configNone {
+		loaded, err := loadProjectConfig(configPath, scope)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		cfg = loaded
+	}
+	if format == "" {
+		format = cfg.Format
+	}
+	if format == "" {
+		format = app.FormatText
+	}
+
+	if !app.ValidFormat(format) {
+		fmt.Fprintf(os.Stderr, translate("error.unknown_format"), format)
+		os.Exit(1)
+	}
+
+	// writeReport emits a machine-readable report and exits non-zero when it has issues.
+	writeReport := func(r app.Report, failed bool) {
+		if err := app.WriteReport(os.Stdout, format, r); err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if failed {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 
 	switch command {
 	case "help":
 		// print basic usage and available commands
 		tprintln(translate("usage.general"))
+		tprintln(translate("usage.config"))
 		tprintln(translate("usage.check"))
+		tprintln(translate("usage.lint"))
 		tprintln(translate("usage.sort"))
 		tprintln(translate("usage.unused"))
+		tprintln(translate("usage.undefined"))
+		tprintln(translate("usage.prune"))
+		tprintln(translate("usage.rename"))
+		tprintln(translate("usage.delete"))
+		tprintln(translate("usage.copy"))
 		tprintln(translate("usage.add"))
+		tprintln(translate("usage.add_all"))
 		tprintln(translate("usage.simple"))
+		tprintln(translate("usage.convert"))
+		tprintln(translate("usage.export_xliff"))
+		tprintln(translate("usage.import_xliff"))
+		tprintln(translate("usage.embed"))
+		tprintln(translate("usage.gen_go"))
+		tprintln(translate("usage.gen_ts"))
 		os.Exit(0)
 
 	case "check":
-		if len(args) < 3 {
+		// --source <lang> makes one language authoritative; --min-completion <percent>
+		// then gates on translation progress instead of failing on every untranslated key
+		source, checkArgs := takeFlag(args[2:], "--source")
+		minCompletionArg, checkArgs := takeFlag(checkArgs, "--min-completion")
+		// the configured source and min_completion apply to the configured
+		// locale files; files on the command line are checked as given
+		configured := len(checkArgs) == 0
+		if source == "" && configured {
+			source = cfg.Source
+		}
+		paths, err := localeFiles(cfg, checkArgs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(paths) < 1 || (minCompletionArg != "" && source == "") {
 			tprintln(translate("usage.check"))
 			os.Exit(1)
 		}
+		useMinCompletion := minCompletionArg != ""
+		var minCompletion float64
+		if useMinCompletion {
+			v, err := strconv.ParseFloat(strings.TrimSuffix(minCompletionArg, "%"), 64)
+			if err != nil || v < 0 || v > 100 {
+				fmt.Fprintf(os.Stderr, translate("Error: %v\n"), fmt.Errorf("invalid --min-completion %q (expected 0-100)", minCompletionArg))
+				os.Exit(1)
+			}
+			minCompletion = v
+		} else if cfg.Check.MinCompletion != nil && source != "" && configured {
+			useMinCompletion = true
+			minCompletion = *cfg.Check.MinCompletion
+		}
 
-		// Accept N json files. Try to derive language code from filename (e.g. "de.json")
-		// or from a parent directory name (e.g. "locales/de/en.json"). If detection
-		// fails, fall back to a safe identifier (basename or fileN). Language detection
-		// is only for reporting, so failures are NOT fatal.
-		files := make(map[string]string)
-		used := make(map[string]bool)
-		langRe := regexp.MustCompile(`^[A-Za-z]{2}([_-][A-Za-z]{2})?$`)
+		// Language detection is only for reporting, so files whose language
+		// cannot be derived are NOT fatal; they are named after the file.
+		files := buildFilesMapFromPaths(paths, cfg)
 
-		for idx, p := range os.Args[2:] {
-			base := filepath.Base(p)
-			name := strings.TrimSuffix(base, filepath.Ext(base))
+		tm, err := app.NewTranslationManager(files)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
 
-			var lang string
-			if langRe.MatchString(name) {
-				lang = name
-			} else {
-				// check parent directory
-				parent := filepath.Base(filepath.Dir(p))
-				if langRe.MatchString(parent) {
-					lang = parent
+		if source != "" {
+			if lang, ok := tm.MatchLanguage(source); ok {
+				source = lang
+			}
+			statuses, err := tm.CheckAgainstSource(source)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+				os.Exit(1)
+			}
+			statuses = filterStatuses(cfg, statuses)
+			mismatches := tm.CheckPlaceholders(source)
+			if !cfg.RuleEnabled(app.RulePlaceholder) {
+				mismatches = nil
+			}
+			conflicts := filterConflicts(cfg, tm.CheckStructure())
+			duplicates := filterIssues(cfg, app.DuplicateIssues(tm.DuplicateKeys()))
+			plurals := filterPlurals(cfg, tm.CheckPlurals())
+			messages := filterMessageFormat(cfg, tm.CheckMessageFormat(source))
+
+			// without a threshold every untranslated key fails the run; orphaned keys only warn
+			failed := len(mismatches) > 0 || len(conflicts) > 0 || len(duplicates) > 0 || pluralsFailed(plurals) || len(messages) > 0
+			var below []app.LanguageStatus
+			for _, st := range statuses {
+				if useMinCompletion && st.Completion() < minCompletion {
+					below = append(below, st)
+				}
+				if !useMinCompletion && len(st.Untranslated) > 0 {
+					failed = true
 				}
 			}
+			failed = failed || len(below) > 0
 
-			if lang == "" {
-				// fallback to basename if useful, otherwise file-<n>
-				if name != "" {
-					lang = name
-				} else {
-					lang = fmt.Sprintf("file-%d", idx+1)
+			if format != app.FormatText {
+				completion := make(map[string]float64, len(statuses))
+				for _, st := range statuses {
+					completion[st.Language] = st.Completion()
 				}
+				issues := append(tm.SourceIssues(source, statuses), tm.StructureIssues(conflicts)...)
+				issues = append(issues, tm.PlaceholderIssues(mismatches)...)
+				issues = append(issues, duplicates...)
+				issues = append(issues, tm.PluralIssues(plurals)...)
+				issues = append(issues, tm.MessageFormatIssues(messages)...)
+				writeReport(app.Report{Command: "check", Languages: tm.Languages, Completion: completion, Issues: issues}, failed)
 			}
 
-			// ensure uniqueness using hyphen suffixes
-			orig := lang
-			i := 1
-			for used[lang] {
-				lang = fmt.Sprintf("%s-%d", orig, i)
-				i++
+			tprintf(translate("check.source_header"), source)
+			for _, st := range statuses {
+				tprintf(translate("check.completion_item"), st.Language, st.Completion(), st.Translated, st.Total,
+					len(st.Untranslated), len(st.Orphaned))
+			}
+			for _, st := range statuses {
+				if len(st.Untranslated) == 0 {
+					continue
+				}
+				tprintf(translate("check.untranslated_count"), len(st.Untranslated), st.Language)
+				for _, key := range st.Untranslated {
+					tprintf(translate("unused.item"), key)
+				}
+			}
+			for _, st := range statuses {
+				if len(st.Orphaned) == 0 {
+					continue
+				}
+				tprintf(translate("check.orphaned_count"), len(st.Orphaned), st.Language, source)
+				for _, key := range st.Orphaned {
+					tprintf(translate("unused.item"), key)
+				}
+			}
+			printConflicts(conflicts)
+			printPlurals(plurals)
+			printMessageFormat(messages)
+			printDuplicates(duplicates)
+			if len(mismatches) > 0 {
+				fmt.Println()
+				tprintf(translate("check.placeholder_count"), len(mismatches), source)
+				for _, m := range mismatches {
+					tprintf(translate("check.placeholder_item"), m.Key, m.Language, m.Reason,
+						strings.Join(m.Expected, " "), strings.Join(m.Found, " "))
+				}
+			}
+			if len(below) > 0 {
+				fmt.Println()
+				for _, st := range below {
+					tprintf(translate("check.below_min_completion"), st.Language, st.Completion(), minCompletion)
+				}
+			}
+			if failed {
+				os.Exit(1)
 			}
-			used[lang] = true
-			files[lang] = p
+			break
 		}
 
-		tm, err := app.NewTranslationManager(files)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
-			os.Exit(1)
+		missing := tm.CheckMissing()
+		if !cfg.RuleEnabled(app.RuleMissing) {
+			missing = nil
+		}
+		conflicts := filterConflicts(cfg, tm.CheckStructure())
+		reference := tm.ReferenceLanguage()
+		mismatches := tm.CheckPlaceholders(reference)
+		if !cfg.RuleEnabled(app.RulePlaceholder) {
+			mismatches = nil
+		}
+		duplicates := filterIssues(cfg, app.DuplicateIssues(tm.DuplicateKeys()))
+		plurals := filterPlurals(cfg, tm.CheckPlurals())
+		messages := filterMessageFormat(cfg, tm.CheckMessageFormat(reference))
+		failed := len(missing) > 0 || len(conflicts) > 0 || len(mismatches) > 0 || len(duplicates) > 0 ||
+			pluralsFailed(plurals) || len(messages) > 0
+
+		if format != app.FormatText {
+			issues := append(tm.MissingIssues(missing), tm.StructureIssues(conflicts)...)
+			issues = append(issues, tm.PlaceholderIssues(mismatches)...)
+			issues = append(issues, duplicates...)
+			issues = append(issues, tm.PluralIssues(plurals)...)
+			issues = append(issues, tm.MessageFormatIssues(messages)...)
+			writeReport(app.Report{Command: "check", Languages: tm.Languages, Issues: issues}, failed)
 		}
 
-		missing := tm.CheckMissing()
 		if len(missing) == 0 {
 			tprintln("All translations complete!")
 		} else {
@@ -167,13 +434,73 @@ func main() {
 			}
 		}
 
+		printConflicts(conflicts)
+		printPlurals(plurals)
+		printMessageFormat(messages)
+		printDuplicates(duplicates)
+
+		if len(mismatches) > 0 {
+			fmt.Println()
+			tprintf(translate("check.placeholder_count"), len(mismatches), reference)
+			for _, m := range mismatches {
+				tprintf(translate("check.placeholder_item"), m.Key, m.Language, m.Reason,
+					strings.Join(m.Expected, " "), strings.Join(m.Found, " "))
+			}
+		}
+
+		if failed {
+			os.Exit(1)
+		}
+
+	case "lint":
+		// lint validates each file on its own, so it also works on a single file;
+		// duplicate keys would otherwise be silently collapsed by the JSON decoder
+		paths, err := localeFiles(cfg, args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(paths) == 0 {
+			tprintln(translate("usage.lint"))
+			os.Exit(1)
+		}
+		issues := make([]app.Issue, 0)
+		for _, p := range paths {
+			fileIssues, err := app.LintFile(p)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+				os.Exit(1)
+			}
+			issues = append(issues, fileIssues...)
+		}
+		issues = filterIssues(cfg, issues)
+
+		if format != app.FormatText {
+			writeReport(app.Report{Command: "lint", Issues: issues}, len(issues) > 0)
+		}
+
+		if len(issues) == 0 {
+			tprintf(translate("lint.ok"), len(paths))
+			break
+		}
+		tprintf(translate("lint.found_count"), len(issues))
+		for _, issue := range issues {
+			tprintf(translate("lint.item"), issue.File, issue.Line, issue.Column, issue.Message)
+		}
+		os.Exit(1)
+
 	case "sort":
-		if len(args) < 3 {
+		paths, err := localeFiles(cfg, args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(paths) == 0 {
 			tprintln(translate("usage.sort"))
 			os.Exit(1)
 		}
 
-		files := buildFilesMapFromPaths(os.Args[2:])
+		files := buildFilesMapFromPaths(paths, cfg)
 
 		tm, err := app.NewTranslationManager(files)
 		if err != nil {
@@ -186,36 +513,55 @@ func main() {
 			os.Exit(1)
 		}
 
-	case "unused":
+	case "unused", "undefined", "missing-in-code", "prune":
 		// Usage: i18n-manager unused <file1.json> <file2.json> -- <project-path> [<project-path>...]
-		if len(args) < 4 {
-			tprintln(translate("usage.unused"))
+		// undefined (alias missing-in-code) takes the same arguments and reports the reverse:
+		// keys referenced in the sources that no language file defines. prune removes the
+		// unused keys from every file (--dry-run only lists them, --keep protects patterns).
+		usageKey := "usage.unused"
+		switch command {
+		case "undefined", "missing-in-code":
+			usageKey = "usage.undefined"
+		case "prune":
+			usageKey = "usage.prune"
+		}
+		dryRun, rest := takeBoolFlag(args[2:], "--dry-run")
+		keep, rest := takeFlags(rest, "--keep")
+		if err := app.ValidKeyPatterns(keep); err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		keep = append(append([]string(nil), cfg.Ignore...), keep...)
+		scanCfg, scanArgs, err := takeScanConfig(rest, cfg.Extract)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
 			os.Exit(1)
 		}
 
-		// find separator `--`
-		sep := -1
-		for i, a := range args[2:] {
+		// files and project paths are separated by `--`; either side
+		// defaults to the project configuration
+		fileArgs, projectPaths := scanArgs, []string(nil)
+		for i, a := range scanArgs {
 			if a == "--" {
-				sep = i + 2
+				fileArgs, projectPaths = scanArgs[:i], scanArgs[i+1:]
 				break
 			}
 		}
-
-		if sep == -1 {
-			tprintln(translate("usage.unused"))
+		fileArgs, err = localeFiles(cfg, fileArgs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
 			os.Exit(1)
 		}
-
-		fileArgs := args[2:sep]
-		projectPaths := args[sep+1:]
+		if len(projectPaths) == 0 {
+			projectPaths = cfg.ExtractPaths()
+		}
 
 		if len(fileArgs) == 0 || len(projectPaths) == 0 {
-			tprintln(translate("usage.unused"))
+			tprintln(translate(usageKey))
 			os.Exit(1)
 		}
 
-		files := buildFilesMapFromPaths(fileArgs)
+		files := buildFilesMapFromPaths(fileArgs, cfg)
 
 		tm, err := app.NewTranslationManager(files)
 		if err != nil {
@@ -223,11 +569,81 @@ func main() {
 			os.Exit(1)
 		}
 
-		unused, err := tm.FindUnusedKeys(projectPaths)
+		if command == "undefined" || command == "missing-in-code" {
+			refs, err := tm.FindUndefinedKeys(projectPaths, scanCfg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+				os.Exit(1)
+			}
+			undefined := make([]app.KeyRef, 0, len(refs))
+			for _, ref := range refs {
+				if !cfg.Ignored(ref.Key) {
+					undefined = append(undefined, ref)
+				}
+			}
+
+			if format != app.FormatText {
+				writeReport(app.Report{Command: "undefined", Languages: tm.Languages, Issues: app.UndefinedIssues(undefined)}, len(undefined) > 0)
+			}
+
+			if len(undefined) == 0 {
+				tprintln(translate("undefined.all_defined"))
+			} else {
+				tprintf(translate("undefined.found_count"), len(undefined))
+				for _, ref := range undefined {
+					tprintf(translate("undefined.item"), ref.Key, ref.File, ref.Line)
+				}
+				os.Exit(1)
+			}
+			break
+		}
+
+		unused, err := tm.FindUnusedKeys(projectPaths, scanCfg)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
 			os.Exit(1)
 		}
+		if command != "prune" {
+			// prune reports ignored keys as kept instead
+			reported := make([]string, 0, len(unused))
+			for _, key := range unused {
+				if !cfg.Ignored(key) {
+					reported = append(reported, key)
+				}
+			}
+			unused = reported
+		}
+
+		if command == "prune" {
+			result, err := tm.Prune(unused, keep, dryRun)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+				os.Exit(1)
+			}
+			if len(result.Removed) == 0 {
+				tprintln(translate("prune.nothing"))
+			} else {
+				countKey := "prune.removed_count"
+				if dryRun {
+					countKey = "prune.dry_run_count"
+				}
+				tprintf(translate(countKey), len(result.Removed), len(result.Files))
+				for _, key := range result.Removed {
+					tprintf(translate("unused.item"), key)
+				}
+			}
+			if len(result.Kept) > 0 {
+				tprintf(translate("prune.kept_count"), len(result.Kept))
+				for _, key := range result.Kept {
+					tprintf(translate("unused.item"), key)
+				}
+			}
+			break
+		}
+
+		if format != app.FormatText {
+			writeReport(app.Report{Command: "unused", Languages: tm.Languages, Issues: tm.UnusedIssues(unused)}, len(unused) > 0)
+		}
 
 		if len(unused) == 0 {
 			tprintln(translate("unused.all_used"))
@@ -236,9 +652,153 @@ func main() {
 			for _, key := range unused {
 				tprintf(translate("unused.item"), key)
 			}
+			os.Exit(1)
+		}
+
+	case "rename":
+		// Usage: i18n-manager rename <old.key> <new.key> <file1.json> [...] [--update-source <path>]
+		sourcePaths, rest := takeFlags(args[2:], "--update-source")
+		scanCfg, rest, err := takeScanConfig(rest, cfg.Extract)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(rest) < 2 {
+			tprintln(translate("usage.rename"))
+			os.Exit(1)
+		}
+		oldKey, newKey := rest[0], rest[1]
+		paths, err := localeFiles(cfg, rest[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(paths) == 0 {
+			tprintln(translate("usage.rename"))
+			os.Exit(1)
+		}
+
+		tm, err := app.NewTranslationManager(buildFilesMapFromPaths(paths, cfg))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+
+		written, err := tm.RenameKey(oldKey, newKey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		tprintf(translate("rename.done"), oldKey, newKey, len(written))
+
+		if len(sourcePaths) > 0 {
+			refs, err := app.RenameSourceKeys(sourcePaths, scanCfg, oldKey, newKey)
+			tprintf(translate("rename.source_count"), len(refs))
+			for _, ref := range refs {
+				tprintf(translate("undefined.item"), ref.Key, ref.File, ref.Line)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+				os.Exit(1)
+			}
+		}
+
+	case "delete", "copy":
+		// Usage: i18n-manager delete <key|prefix> <file1.json> [...]
+		//        i18n-manager copy <src.key> <dst.key> <file1.json> [...]
+		keyArgs := 1
+		if command == "copy" {
+			keyArgs = 2
+		}
+		if len(args) < 2+keyArgs {
+			tprintln(translate("usage." + command))
+			os.Exit(1)
+		}
+		paths, err := localeFiles(cfg, args[2+keyArgs:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(paths) == 0 {
+			tprintln(translate("usage." + command))
+			os.Exit(1)
+		}
+
+		tm, err := app.NewTranslationManager(buildFilesMapFromPaths(paths, cfg))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+
+		var edits []app.KeyEdit
+		if command == "delete" {
+			edits, err = tm.DeleteKey(args[2])
+		} else {
+			edits, err = tm.CopyKey(args[2], args[3])
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+
+		if command == "delete" {
+			tprintf(translate("delete.done"), args[2])
+		} else {
+			tprintf(translate("copy.done"), args[2], args[3])
+		}
+		for _, edit := range edits {
+			if len(edit.Keys) == 0 {
+				tprintf(translate("edit.file_unchanged"), edit.File)
+				continue
+			}
+			tprintf(translate("edit.file_changed"), edit.File, len(edit.Keys))
 		}
 
 	case "add":
+		// add --all <file1.json> [...] <key> [lang=value ...] [--fill empty|todo|source] [--source <lang>]
+		all, rest := takeBoolFlag(args[2:], "--all")
+		if all {
+			fill, rest := takeFlag(rest, "--fill")
+			source, rest := takeFlag(rest, "--source")
+			files, key, values, ok := splitAddAllArgs(rest)
+			if !ok && cfg.Path != "" {
+				// without leading files the key goes to the configured locales
+				configured, err := cfg.LocaleFiles()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+					os.Exit(1)
+				}
+				files, key, values, ok = splitAddAllArgs(append(configured, rest...))
+			}
+			if !ok {
+				tprintln(translate("usage.add_all"))
+				os.Exit(1)
+			}
+
+			tm, err := app.NewTranslationManager(buildFilesMapFromPaths(files, cfg))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+				os.Exit(1)
+			}
+			if source == "" {
+				source = cfg.Source
+			}
+			if source == "" {
+				source = tm.ReferenceLanguage()
+			}
+
+			written, err := tm.AddToAll(key, values, fill, source)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+				os.Exit(1)
+			}
+			tprintf(translate("add.all_done"), key, len(written))
+			for _, l := range tm.Languages {
+				tprintf(translate("add.all_item"), tm.FilePath(l), written[l])
+			}
+			break
+		}
+
 		if len(args) < 5 {
 			tprintln(translate("usage.add"))
 			os.Exit(1)
@@ -255,6 +815,234 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "convert":
+		// Usage: i18n-manager convert <input> <output> [--source <source.json>]
+		source, rest := takeFlag(args[2:], "--source")
+		if len(rest) != 2 {
+			tprintln(translate("usage.convert"))
+			os.Exit(1)
+		}
+		in, out := rest[0], rest[1]
+
+		// the PO side determines the language written to the header
+		var convLang string
+		for l := range buildFilesMapFromPaths([]string{out}, cfg) {
+			convLang = l
+		}
+
+		tm := &app.TranslationManager{}
+		written, err := tm.ConvertFile(in, out, convLang, source)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		tprintf(translate("convert.done"), in, written)
+
+	case "export-xliff":
+		// Usage: i18n-manager export-xliff <file1.json> <file2.json> [...] [--source en] [--version 1.2|2.0] [--out dir]
+		source, rest := takeFlag(args[2:], "--source")
+		version, rest := takeFlag(rest, "--version")
+		outDir, rest := takeFlag(rest, "--out")
+		rest, err := localeFiles(cfg, rest)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(rest) < 2 {
+			tprintln(translate("usage.export_xliff"))
+			os.Exit(1)
+		}
+		if version == "" {
+			version = app.XLIFF12
+		}
+		if outDir == "" {
+			outDir = "."
+		}
+
+		tm, err := app.NewTranslationManager(buildFilesMapFromPaths(rest, cfg))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if source == "" {
+			source = cfg.Source
+		}
+		if source == "" {
+			source = tm.ReferenceLanguage()
+		}
+
+		written, err := tm.ExportXLIFF(source, version, outDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		for _, path := range written {
+			tprintf(translate("xliff.written"), path)
+		}
+
+	case "import-xliff":
+		// Usage: i18n-manager import-xliff <file.xlf> <file1.json> <file2.json> [...] [--force]
+		force, rest := takeBoolFlag(args[2:], "--force")
+		if len(rest) < 1 {
+			tprintln(translate("usage.import_xliff"))
+			os.Exit(1)
+		}
+		paths, err := localeFiles(cfg, rest[1:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(paths) == 0 {
+			tprintln(translate("usage.import_xliff"))
+			os.Exit(1)
+		}
+
+		tm, err := app.NewTranslationManager(buildFilesMapFromPaths(paths, cfg))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+
+		res, err := tm.ImportXLIFF(rest[0], force)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		tprintf(translate("xliff.imported"), len(res.Applied), res.File)
+		if len(res.Conflicts) > 0 {
+			tprintf(translate("xliff.conflict_count"), len(res.Conflicts))
+			for _, c := range res.Conflicts {
+				tprintf(translate("xliff.conflict_item"), c.Key, c.Reason, c.Current, c.Incoming)
+			}
+			os.Exit(1)
+		}
+
+	case "embed":
+		// Usage: i18n-manager embed <file.json|glob> [...] [--out file.go] [--package name] [--var Name] [--fs]
+		// Under go generate the package defaults to $GOPACKAGE.
+		out, rest := takeFlag(args[2:], "--out")
+		pkg, rest := takeFlag(rest, "--package")
+		varName, rest := takeFlag(rest, "--var")
+		useFS, rest := takeBoolFlag(rest, "--fs")
+		rest, err := localeFiles(cfg, rest)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(rest) == 0 {
+			tprintln(translate("usage.embed"))
+			os.Exit(1)
+		}
+		if out == "" {
+			out = "embedded_translations.go"
+		}
+		if pkg == "" {
+			pkg = os.Getenv("GOPACKAGE")
+		}
+
+		src, locales, err := app.GenerateEmbed(app.EmbedOptions{Package: pkg, Var: varName, Out: out, FS: useFS, Inputs: rest})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		written, err := writeGenerated(out, src)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if written {
+			tprintf(translate("embed.written"), out, len(locales))
+		} else {
+			tprintf(translate("embed.unchanged"), out, len(locales))
+		}
+
+	case "gen-go":
+		// Usage: i18n-manager gen-go <file1.json> [...] [--out file.go] [--package name] [--source lang]
+		out, rest := takeFlag(args[2:], "--out")
+		pkg, rest := takeFlag(rest, "--package")
+		source, rest := takeFlag(rest, "--source")
+		rest, err := localeFiles(cfg, rest)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(rest) == 0 {
+			tprintln(translate("usage.gen_go"))
+			os.Exit(1)
+		}
+		if source == "" {
+			source = cfg.Source
+		}
+		if out == "" {
+			out = "keys_gen.go"
+		}
+		if pkg == "" {
+			pkg = os.Getenv("GOPACKAGE")
+		}
+
+		tm, err := app.NewTranslationManager(buildFilesMapFromPaths(rest, cfg))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		src, err := tm.GenerateGo(app.GoOptions{Package: pkg, Out: out, Source: source})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		written, err := writeGenerated(out, src)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if written {
+			tprintf(translate("gen.written"), out)
+		} else {
+			tprintf(translate("gen.unchanged"), out)
+		}
+
+	case "gen-ts":
+		// Usage: i18n-manager gen-ts <file1.json> [...] [--out file.d.ts] [--source lang] [--augment i18next|vue-i18n]
+		out, rest := takeFlag(args[2:], "--out")
+		source, rest := takeFlag(rest, "--source")
+		augment, rest := takeFlag(rest, "--augment")
+		rest, err := localeFiles(cfg, rest)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if len(rest) == 0 {
+			tprintln(translate("usage.gen_ts"))
+			os.Exit(1)
+		}
+		if source == "" {
+			source = cfg.Source
+		}
+		if out == "" {
+			out = "i18n.d.ts"
+		}
+
+		tm, err := app.NewTranslationManager(buildFilesMapFromPaths(rest, cfg))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		src, err := tm.GenerateTS(app.TSOptions{Source: source, Augment: augment})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		written, err := writeGenerated(out, src)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translate("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		if written {
+			tprintf(translate("gen.written"), out)
+		} else {
+			tprintf(translate("gen.unchanged"), out)
+		}
+
 	case "simple":
 		// Usage: i18n-manager simple <translation.json> <key> [<fallback>]
 		if len(args) < 4 {
@@ -290,29 +1078,38 @@ func main() {
 	}
 }
 
+// writeGenerated writes generated source to path unless the file already
+// has that content, so make and go build see no change. Missing directories
+// are created. It reports whether the file was written.
+func writeGenerated(path string, src []byte) (bool, error) {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, src) {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(path, src, 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // buildFilesMapFromPaths accepts a slice of paths to JSON files and returns a map
-// of language -> path. It uses the same tolerant detection rules used in the
-// check command (2-letter codes, parent dir, basename, fallback to file-<n>).
-// buildFilesMapFromPaths derives language identifiers from paths and returns a map[lang]path.
-func buildFilesMapFromPaths(paths []string) map[string]string {
+// of language -> path. The language comes from the {lang} part of the configured
+// layout, else from tolerant detection (2-letter codes, parent dir, basename,
+// fallback to file-<n>).
+func buildFilesMapFromPaths(paths []string, cfg *app.Config) map[string]string {
 	files := make(map[string]string)
 	used := make(map[string]bool)
-	langRe := regexp.MustCompile(`^[A-Za-z]{2}([_-][A-Za-z]{2})?$`)
 
 	for idx, p := range paths {
 		base := filepath.Base(p)
 		name := strings.TrimSuffix(base, filepath.Ext(base))
 
-		var lang string
-		if langRe.MatchString(name) {
-			lang = name
-		} else {
-			parent := filepath.Base(filepath.Dir(p))
-			if langRe.MatchString(parent) {
-				lang = parent
-			}
+		lang := cfg.Language(p)
+		if lang == "" {
+			lang = simpletrans.LanguageFromPath(p)
 		}
-
 		if lang == "" {
 			if name != "" {
 				lang = name
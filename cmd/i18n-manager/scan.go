@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mlechner911/i18ntool/internal/app"
+)
+
+// takeScanConfig removes the source scanning options from args and builds the
+// extractor configuration:
+//
+//	--preset name      use a built-in extractor instead of the defaults (repeatable)
+//	--pattern glob=re  add a regex extractor; the first group is the key (repeatable)
+//	--skip dir         skip directories with this name in addition to the defaults
+//	--go-func Name:N   treat argument N (1-based) of Name as key in Go files
+//
+// Presets on the command line replace those of the project configuration;
+// the other options add to it.
+func takeScanConfig(args []string, extract app.ExtractConfig) (app.ScanConfig, []string, error) {
+	presetNames, args := takeFlags(args, "--preset")
+	patterns, args := takeFlags(args, "--pattern")
+	skips, args := takeFlags(args, "--skip")
+	goFuncSpecs, args := takeFlags(args, "--go-func")
+	if len(presetNames) == 0 {
+		presetNames = extract.Presets
+	}
+	patterns = append(append([]string(nil), extract.Patterns...), patterns...)
+	skips = append(append([]string(nil), extract.Skip...), skips...)
+	goFuncSpecs = append(append([]string(nil), extract.GoFuncs...), goFuncSpecs...)
+
+	cfg := app.DefaultScanConfig()
+	if len(presetNames) > 0 {
+		cfg.Extractors = nil
+		for _, name := range presetNames {
+			e, err := app.Preset(name)
+			if err != nil {
+				return cfg, args, err
+			}
+			cfg.Extractors = append(cfg.Extractors, e)
+		}
+	}
+	for _, spec := range patterns {
+		e, err := app.ParsePattern(spec)
+		if err != nil {
+			return cfg, args, err
+		}
+		cfg.Extractors = append(cfg.Extractors, e)
+	}
+	cfg.SkipDirs = append(append([]string(nil), app.DefaultSkipDirs...), skips...)
+
+	var goFuncs []app.GoFunc
+	for _, spec := range goFuncSpecs {
+		f, err := app.ParseGoFunc(spec)
+		if err != nil {
+			return cfg, args, err
+		}
+		goFuncs = append(goFuncs, f)
+	}
+	hasGo := false
+	for i := range cfg.Extractors {
+		if len(cfg.Extractors[i].GoFuncs) > 0 {
+			cfg.Extractors[i].GoFuncs = append(cfg.Extractors[i].GoFuncs, goFuncs...)
+			hasGo = true
+		}
+	}
+	if len(goFuncs) > 0 && !hasGo {
+		return cfg, args, fmt.Errorf("--go-func %s has no effect without the go preset", goFuncSpecs[0])
+	}
+	return cfg, args, nil
+}
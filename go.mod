@@ -1,3 +1,5 @@
 module github.com/mlechner911/i18ntool
 
 go 1.24.2
+
+require gopkg.in/yaml.v3 v3.0.1
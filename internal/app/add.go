@@ -1,98 +1,166 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
-	"time"
 )
 
 // AddTranslation adds a new nested key to the specified JSON file (creates backup).
+// The rest of the file keeps its order and formatting.
 func (tm *TranslationManager) AddTranslation(filePath, key, value string) error {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("reading %s: %w", filePath, err)
 	}
 
-	backupPath := filePath + ".backup." + time.Now().Format("20060102-150405")
-	if err := os.WriteFile(backupPath, content, 0644); err != nil {
-		return fmt.Errorf("creating backup %s: %w", backupPath, err)
+	if err := writeBackup(filePath, content); err != nil {
+		return err
 	}
-	fmt.Printf("Backup created: %s\n", backupPath)
 
-	var data map[string]interface{}
-	if err := json.Unmarshal(content, &data); err != nil {
+	doc, err := parseDocument(content)
+	if err != nil {
 		return fmt.Errorf("parsing %s: %w", filePath, err)
 	}
 
-	if tm.keyExists(key, data) {
+	if tm.keyExists(key, doc.root) {
 		return fmt.Errorf("key '%s' already exists in %s", key, filePath)
 	}
 
-	if err := tm.addNestedKey(data, key, value); err != nil {
+	if err := tm.addNestedKey(doc.root, key, stringNode(value)); err != nil {
 		return fmt.Errorf("adding key '%s': %w", key, err)
 	}
 
-	sorted := tm.sortMap(data)
-	newContent, err := json.MarshalIndent(sorted, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshaling JSON: %w", err)
-	}
-
-	if err := os.WriteFile(filePath, newContent, 0644); err != nil {
-		return fmt.Errorf("writing %s: %w", filePath, err)
+	if err := writeDocument(filePath, doc); err != nil {
+		return err
 	}
 
 	fmt.Printf("Added translation '%s' = '%s' to %s\n", key, value, filePath)
 	return nil
 }
 
-// keyExists returns true if the dotted key already exists in the provided data.
-func (tm *TranslationManager) keyExists(key string, data map[string]interface{}) bool {
+// keyExists returns true if the dotted key already exists in the provided document node.
+func (tm *TranslationManager) keyExists(key string, root *jsonNode) bool {
+	return lookupNode(root, key) != nil
+}
+
+// addNestedKey inserts a value at a dotted key, creating intermediate objects.
+// Every object on the path is marked for re-encoding; siblings stay untouched.
+func (tm *TranslationManager) addNestedKey(root *jsonNode, key string, value *jsonNode) error {
 	parts := strings.Split(key, ".")
-	current := data
+	current := root
 
 	for i, part := range parts {
-		if val, exists := current[part]; exists {
-			if i == len(parts)-1 {
-				return true
-			}
-			if nested, ok := val.(map[string]interface{}); ok {
-				current = nested
-			} else {
-				return false
+		if current.object == nil {
+			return fmt.Errorf("cannot add nested key '%s': '%s' is not an object", key, strings.Join(parts[:i], "."))
+		}
+		current.raw = nil
+
+		if i == len(parts)-1 {
+			if current.object.get(part) != nil {
+				return fmt.Errorf("key '%s' already exists", key)
 			}
-		} else {
-			return false
+			current.object.insert(part, value)
+			return nil
+		}
+
+		next := current.object.get(part)
+		if next == nil {
+			next = &jsonNode{object: &jsonObject{}}
+			current.object.insert(part, next)
 		}
+		current = next
 	}
-	return false
+
+	return nil
 }
 
-// addNestedKey inserts a nested dotted key into the provided map, creating intermediate objects.
-func (tm *TranslationManager) addNestedKey(data map[string]interface{}, key, value string) error {
-	parts := strings.Split(key, ".")
-	current := data
+// Fill modes for languages that get no explicit value in AddToAll.
+const (
+	FillEmpty  = "empty"  // empty string
+	FillTodo   = "todo"   // the TodoMarker
+	FillSource = "source" // a copy of the source language value
+)
 
-	for i, part := range parts {
-		if i == len(parts)-1 {
-			current[part] = value
-			return nil
+// TodoMarker is the value written for FillTodo.
+const TodoMarker = "TODO"
+
+// AddToAll adds key to every loaded language. values maps language codes to
+// their text; other languages are filled according to fill, where
+// FillSource copies values[source]. The change is transactional: if the key
+// exists or cannot be added in any file, nothing is written. All files are
+// rendered before the first one is written; if a write fails anyway, the
+// error names the files already written. It returns the value written per
+// language.
+func (tm *TranslationManager) AddToAll(key string, values map[string]string, fill, source string) (map[string]string, error) {
+	resolved := make(map[string]string, len(tm.Languages))
+	for code, value := range values {
+		lang, ok := tm.MatchLanguage(code)
+		if !ok {
+			return nil, fmt.Errorf("language %q is not loaded", code)
 		}
+		resolved[lang] = value
+	}
 
-		if val, exists := current[part]; exists {
-			if nested, ok := val.(map[string]interface{}); ok {
-				current = nested
-			} else {
-				return fmt.Errorf("cannot add nested key '%s': '%s' is not an object", key, strings.Join(parts[:i+1], "."))
-			}
-		} else {
-			newObj := make(map[string]interface{})
-			current[part] = newObj
-			current = newObj
+	var filler string
+	switch fill {
+	case FillEmpty, "":
+	case FillTodo:
+		filler = TodoMarker
+	case FillSource:
+		lang, ok := tm.MatchLanguage(source)
+		if !ok {
+			return nil, fmt.Errorf("source language %q is not loaded", source)
+		}
+		value, ok := resolved[lang]
+		if !ok {
+			return nil, fmt.Errorf("no value given for source language %s", lang)
+		}
+		filler = value
+	default:
+		return nil, fmt.Errorf("unknown fill mode %q (expected %s, %s or %s)", fill, FillEmpty, FillTodo, FillSource)
+	}
+	for _, lang := range tm.Languages {
+		if _, ok := resolved[lang]; !ok {
+			resolved[lang] = filler
 		}
 	}
 
-	return nil
+	for _, lang := range tm.Languages {
+		root := tm.docs[lang].root
+		if tm.keyExists(key, root) {
+			return nil, fmt.Errorf("key '%s' already exists in %s", key, tm.files[lang])
+		}
+		if err := tm.addNestedKey(root.clone(), key, stringNode(resolved[lang])); err != nil {
+			return nil, fmt.Errorf("%s: %w", tm.files[lang], err)
+		}
+	}
+
+	rendered := make(map[string][]byte, len(tm.Languages))
+	for _, lang := range tm.Languages {
+		if err := tm.addNestedKey(tm.docs[lang].root, key, stringNode(resolved[lang])); err != nil {
+			return nil, fmt.Errorf("%s: %w", tm.files[lang], err)
+		}
+		rendered[lang] = tm.docs[lang].Bytes()
+	}
+
+	var written []string
+	for _, lang := range tm.Languages {
+		path := tm.files[lang]
+		err := backupIfExists(path)
+		if err == nil {
+			if err = os.WriteFile(path, rendered[lang], 0644); err != nil {
+				err = fmt.Errorf("writing %s: %w", path, err)
+			}
+		}
+		if err != nil {
+			if len(written) > 0 {
+				return nil, fmt.Errorf("%w (already written: %s)", err, strings.Join(written, ", "))
+			}
+			return nil, err
+		}
+		tm.data[lang] = tm.docs[lang].toMap()
+		written = append(written, path)
+	}
+	return resolved, nil
 }
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddToAll_FillModes(t *testing.T) {
+	for _, c := range []struct {
+		fill string
+		want string
+	}{
+		{FillEmpty, ""},
+		{FillTodo, TodoMarker},
+		{FillSource, "Save"},
+	} {
+		dir := t.TempDir()
+		files := map[string]string{}
+		for _, lang := range []string{"en", "de", "fr"} {
+			files[lang] = writeLocale(t, dir, lang+".json", `{"menu": {"open": "x"}}`)
+		}
+		tm, err := NewTranslationManager(files)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := tm.AddToAll("menu.save", map[string]string{"en": "Save", "de": "Speichern"}, c.fill, "en")
+		if err != nil {
+			t.Fatalf("%s: AddToAll: %v", c.fill, err)
+		}
+		want := map[string]string{"en": "Save", "de": "Speichern", "fr": c.want}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: want %v, got %v", c.fill, want, got)
+		}
+		data, _ := readJSONFile(files["fr"])
+		if v := tm.flattenKeys("", data)["menu.save"]; v != c.want {
+			t.Fatalf("%s: fr.json has %v", c.fill, v)
+		}
+	}
+}
+
+func TestAddToAll_IsTransactional(t *testing.T) {
+	dir := t.TempDir()
+	en := writeLocale(t, dir, "en.json", `{"menu": {}}`)
+	de := writeLocale(t, dir, "de.json", `{"menu": "not an object"}`)
+	tm, err := NewTranslationManager(map[string]string{"en": en, "de": de})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tm.AddToAll("menu.save", map[string]string{"en": "Save"}, FillEmpty, "en"); err == nil {
+		t.Fatalf("expected an error for the blocked path in de.json")
+	}
+	if got, _ := os.ReadFile(en); string(got) != `{"menu": {}}` {
+		t.Fatalf("en.json was written: %s", got)
+	}
+	if _, err := tm.AddToAll("x", map[string]string{"it": "ciao"}, FillEmpty, "en"); err == nil {
+		t.Fatalf("expected an error for an unknown language")
+	}
+	if _, err := tm.AddToAll("x", nil, FillSource, "en"); err == nil {
+		t.Fatalf("expected an error for a missing source value")
+	}
+}
+
+func TestAddToAll_NamesFilesWrittenBeforeAFailure(t *testing.T) {
+	dir := t.TempDir()
+	de := writeLocale(t, dir, "de.json", `{"menu": {}}`)
+	en := writeLocale(t, dir, "en.json", `{"menu": {}}`)
+	tm, err := NewTranslationManager(map[string]string{"en": en, "de": de})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// en.json is written after de.json and can no longer be replaced
+	if err := os.Remove(en); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(en, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tm.AddToAll("menu.save", map[string]string{"en": "Save"}, FillEmpty, "en")
+	if err == nil || !strings.Contains(err.Error(), "already written: "+de) {
+		t.Fatalf("expected an error naming %s, got %v", de, err)
+	}
+}
@@ -2,12 +2,19 @@ package app
 
 import "fmt"
 
-// CheckMissing returns a list of keys that have missing translations.
+// CheckMissing returns a list of keys that have missing translations. Keys
+// involved in an object-vs-value conflict are left to CheckStructure, plural
+// forms (item_one, item_few) to CheckPlurals since languages need different ones.
 func (tm *TranslationManager) CheckMissing() []MissingTranslation {
 	allKeys := tm.GetAllKeys()
 	missing := make([]MissingTranslation, 0)
+	conflicts := tm.typeConflictRoots()
+	plurals := tm.pluralBases()
 
 	for _, key := range allKeys {
+		if coveredBy(key, conflicts) || isPluralForm(key, plurals) {
+			continue
+		}
 		translations := make(map[string]string)
 		hasMissing := false
 
@@ -0,0 +1,252 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mlechner911/i18ntool/internal/messageformat"
+)
+
+// Kinds of codegenEntry, which decide how generated code renders a key.
+const (
+	entryText    = "text"    // plain value or text/template, rendered with T
+	entryPrintf  = "printf"  // fmt verbs, rendered with T and then Sprintf
+	entryPlural  = "plural"  // i18next plural base key, rendered with Plural
+	entryMessage = "message" // ICU MessageFormat or {name} tokens, rendered with Format
+)
+
+// codegenParam is an argument of a generated accessor. Field is the data or
+// MessageFormat argument name; printf arguments have Arg and Verb instead.
+type codegenParam struct {
+	Field string
+	Arg   int
+	Verb  string
+	Type  string // MessageFormat argument type, e.g. plural or select
+}
+
+// codegenEntry describes one key for the gen-go and gen-ts generators.
+type codegenEntry struct {
+	Key    string
+	Value  string // source language value; the other form for plurals
+	Kind   string
+	Params []codegenParam
+	Forms  []string // plural form keys of a plural base
+	Form   bool     // a plural form, rendered through its base
+}
+
+// templateFieldRe matches the root field of a template action such as .Name
+// in {{.Name}}, {{if .Name}} or {{printf "%d" .Count}}, and the path below
+// it, as in .User.Name.
+var templateFieldRe = regexp.MustCompile(`(?:^|[\s(|,$])\.([A-Za-z_]\w*)((?:\.\w+)*)`)
+
+// templateFields returns the root fields the template actions of s use, in
+// order of first appearance.
+func templateFields(s string) []string {
+	var fields []string
+	for _, m := range templateRe.FindAllStringSubmatch(s, -1) {
+		for _, f := range templateFieldRe.FindAllStringSubmatch(m[1], -1) {
+			if !containsString(fields, f[1]) {
+				fields = append(fields, f[1])
+			}
+		}
+	}
+	return fields
+}
+
+// codegenEntries describes every key of the loaded files, as listed by
+// GetAllKeys, for code generation. Values come from source, or from the
+// first language that has the key. Plural forms (item_one, item_other) are
+// also grouped under their base key. Keys whose value is not a string are
+// skipped.
+func (tm *TranslationManager) codegenEntries(source string) ([]codegenEntry, error) {
+	if _, ok := tm.data[source]; !ok {
+		return nil, fmt.Errorf("language %s is not loaded", source)
+	}
+	langs := append([]string{source}, tm.Languages...)
+	flats := make(map[string]map[string]interface{}, len(langs))
+	for _, lang := range langs {
+		flats[lang] = tm.flattenKeys("", tm.data[lang])
+	}
+	value := func(key string) (string, bool) {
+		for _, lang := range langs {
+			if s, ok := flats[lang][key].(string); ok {
+				return s, true
+			}
+		}
+		return "", false
+	}
+
+	bases := tm.pluralBases()
+	var entries []codegenEntry
+	plurals := make(map[string]*codegenEntry)
+	for _, key := range tm.GetAllKeys() {
+		s, ok := value(key)
+		if !ok {
+			continue
+		}
+		entry := codegenEntry{Key: key, Value: s}
+		if base, _, ok := splitPluralKey(key); ok && bases[base] {
+			p := plurals[base]
+			if p == nil {
+				p = &codegenEntry{Key: base, Kind: entryPlural}
+				plurals[base] = p
+			}
+			p.Forms = append(p.Forms, key)
+			if strings.HasSuffix(key, "_other") || p.Value == "" {
+				p.Value = s
+			}
+			for _, f := range templateFields(s) {
+				if f != "Count" && f != "count" && !hasField(p.Params, f) {
+					p.Params = append(p.Params, codegenParam{Field: f})
+				}
+			}
+			entry.Form = true
+		}
+		describeValue(&entry)
+		entries = append(entries, entry)
+	}
+	for _, p := range plurals {
+		entries = append(entries, *p)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// describeValue sets the kind and parameters of a non-plural entry.
+func describeValue(e *codegenEntry) {
+	e.Kind = entryText
+	var printf []codegenParam
+	hasBrace := false
+	for _, p := range extractPlaceholders(e.Value) {
+		switch p.Kind {
+		case PlaceholderPrintf:
+			printf = append(printf, codegenParam{Arg: p.Arg, Verb: p.Name})
+		case PlaceholderBrace:
+			hasBrace = true
+		}
+	}
+	if hasBrace || messageformat.LooksLikeICU(e.Value) {
+		if m, err := messageformat.Parse(e.Value); err == nil {
+			e.Kind = entryMessage
+			args := m.Arguments()
+			names := make([]string, 0, len(args))
+			for name := range args {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				e.Params = append(e.Params, codegenParam{Field: name, Type: args[name]})
+			}
+			return
+		}
+	}
+	for _, f := range templateFields(e.Value) {
+		e.Params = append(e.Params, codegenParam{Field: f})
+	}
+	if len(printf) > 0 {
+		e.Kind = entryPrintf
+		// one parameter per argument; the first verb of an argument wins
+		sort.SliceStable(printf, func(i, j int) bool { return printf[i].Arg < printf[j].Arg })
+		for i, p := range printf {
+			if i > 0 && printf[i-1].Arg == p.Arg {
+				continue
+			}
+			for arg := len(printfParams(e.Params)) + 1; arg < p.Arg; arg++ {
+				e.Params = append(e.Params, codegenParam{Arg: arg, Verb: "v"}) // unused gap
+			}
+			e.Params = append(e.Params, p)
+		}
+	}
+}
+
+func hasField(params []codegenParam, field string) bool {
+	for _, p := range params {
+		if p.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// printfParams returns the printf arguments of params.
+func printfParams(params []codegenParam) []codegenParam {
+	var out []codegenParam
+	for _, p := range params {
+		if p.Arg > 0 {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// camelCase joins the letter and digit runs of key, capitalising each:
+// "errors.network_timeout" becomes "ErrorsNetworkTimeout".
+func camelCase(key string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// exportedName is camelCase prefixed with K when the result would not be an
+// exported identifier.
+func exportedName(key string) string {
+	name := camelCase(key)
+	if first, _ := utf8.DecodeRuneInString(name); !unicode.IsUpper(first) {
+		name = "K" + name
+	}
+	return name
+}
+
+// lowerName converts a field such as "UserName" or "user-name" into a
+// parameter name such as "userName".
+func lowerName(field string) string {
+	name := []rune(camelCase(field))
+	if len(name) == 0 || unicode.IsDigit(name[0]) {
+		return "arg" + string(name)
+	}
+	name[0] = unicode.ToLower(name[0])
+	return string(name)
+}
+
+// uniqueNames assigns each entry a distinct exported name, adding a numeric
+// suffix on collisions. Reserved names are never used. With a prefix, name
+// and prefix+name are both claimed, so generated constants such as KeyFoo
+// cannot clash with functions.
+func uniqueNames(entries []codegenEntry, prefix string, reserved ...string) []string {
+	used := make(map[string]bool)
+	for _, r := range reserved {
+		used[r] = true
+	}
+	taken := func(name string) bool {
+		return used[name] || (prefix != "" && used[prefix+name])
+	}
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		base := exportedName(e.Key)
+		name := base
+		for n := 2; taken(name); n++ {
+			name = fmt.Sprintf("%s%d", base, n)
+		}
+		used[name] = true
+		if prefix != "" {
+			used[prefix+name] = true
+		}
+		names[i] = name
+	}
+	return names
+}
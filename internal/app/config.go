@@ -0,0 +1,251 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileNames are the project configuration files FindConfig looks for,
+// in order of preference.
+var ConfigFileNames = []string{".i18ntool.yaml", ".i18ntool.yml", ".i18ntool.json"}
+
+// Config is a project configuration file. Paths and globs in it are relative
+// to the directory of the file. The zero Config is valid and configures
+// nothing, so commands can use it when no file is found.
+type Config struct {
+	Path    string        `yaml:"-" json:"-"`             // file the configuration was loaded from
+	Locales []string      `yaml:"locales" json:"locales"` // locale files or glob patterns
+	Layout  string        `yaml:"layout" json:"layout"`   // locale path with a {lang} placeholder, e.g. locales/{lang}/messages.json
+	Source  string        `yaml:"source" json:"source"`   // source language for check, export-xliff, gen-go and gen-ts
+	Format  string        `yaml:"format" json:"format"`   // default --format
+	Ignore  []string      `yaml:"ignore" json:"ignore"`   // key patterns never reported as unused or undefined, and kept by prune
+	Extract ExtractConfig `yaml:"extract" json:"extract"`
+	Check   CheckConfig   `yaml:"check" json:"check"`
+
+	layoutRe *regexp.Regexp
+}
+
+// ExtractConfig holds the source scanning settings of unused, undefined,
+// prune and rename; each mirrors the command line option of the same name.
+type ExtractConfig struct {
+	Paths    []string `yaml:"paths" json:"paths"`       // project paths scanned when none are given
+	Presets  []string `yaml:"presets" json:"presets"`   // --preset
+	Patterns []string `yaml:"patterns" json:"patterns"` // --pattern
+	Skip     []string `yaml:"skip" json:"skip"`         // --skip
+	GoFuncs  []string `yaml:"go_funcs" json:"go_funcs"` // --go-func
+}
+
+// CheckConfig holds the settings of check.
+type CheckConfig struct {
+	MinCompletion *float64 `yaml:"min_completion" json:"min_completion"` // --min-completion; requires a source language
+	Disable       []string `yaml:"disable" json:"disable"`               // rules that are not reported
+}
+
+// FindConfig looks for a configuration file in dir and its parents and
+// returns its path, relative to dir when dir is relative, or "" when there
+// is none.
+func FindConfig(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if p, err := ConfigIn(dir); err != nil || p != "" {
+			return p, err
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return "", nil
+		}
+		abs = parent
+		dir = filepath.Join(dir, "..")
+	}
+}
+
+// ConfigIn looks for a configuration file in dir itself, without walking up,
+// and returns its path or "" when there is none.
+func ConfigIn(dir string) (string, error) {
+	for _, name := range ConfigFileNames {
+		p := filepath.Join(dir, name)
+		_, err := os.Stat(p)
+		if err == nil {
+			return p, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// LoadConfig reads and validates a configuration file. Files ending in .json
+// are JSON, all others YAML. Unknown fields are an error, so typos do not
+// silently fall back to defaults.
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	c := &Config{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(c)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(content))
+		dec.KnownFields(true)
+		err = dec.Decode(c)
+	}
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	c.Path = path
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+func (c *Config) validate() error {
+	if c.Format != "" && !ValidFormat(c.Format) {
+		return fmt.Errorf("unknown format %q", c.Format)
+	}
+	if c.Layout != "" {
+		if strings.Count(c.Layout, "{lang}") != 1 {
+			return fmt.Errorf("layout %q must contain {lang} once", c.Layout)
+		}
+		c.layoutRe = layoutRegexp(c.Layout)
+	}
+	if err := ValidKeyPatterns(c.Ignore); err != nil {
+		return err
+	}
+	for _, rule := range c.Check.Disable {
+		if _, ok := ruleDescriptions[rule]; !ok {
+			return fmt.Errorf("unknown rule %q in check.disable", rule)
+		}
+	}
+	if m := c.Check.MinCompletion; m != nil {
+		if *m < 0 || *m > 100 {
+			return fmt.Errorf("check.min_completion %v is not between 0 and 100", *m)
+		}
+		if c.Source == "" {
+			return fmt.Errorf("check.min_completion requires a source language")
+		}
+	}
+	return nil
+}
+
+// layoutRegexp matches slash-separated paths against a layout; {lang} is the
+// first group and * matches within one path element.
+func layoutRegexp(layout string) *regexp.Regexp {
+	before, after, _ := strings.Cut(filepath.ToSlash(layout), "{lang}")
+	glob := func(s string) string {
+		return strings.ReplaceAll(regexp.QuoteMeta(s), `\*`, `[^/]*`)
+	}
+	return regexp.MustCompile("^" + glob(before) + `([^/]+)` + glob(after) + "$")
+}
+
+// Dir returns the directory the paths of the configuration are relative to.
+func (c *Config) Dir() string {
+	if c.Path == "" {
+		return "."
+	}
+	return filepath.Dir(c.Path)
+}
+
+// resolve makes a path of the configuration usable from the working directory.
+func (c *Config) resolve(p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(c.Dir(), filepath.FromSlash(p))
+}
+
+// LocaleFiles expands the locale patterns, or the layout with {lang} as a
+// wildcard when there are none, and returns the sorted files. Sort backups
+// are skipped and a pattern matching no file is an error.
+func (c *Config) LocaleFiles() ([]string, error) {
+	patterns := c.Locales
+	if len(patterns) == 0 && c.Layout != "" {
+		patterns = []string{strings.Replace(c.Layout, "{lang}", "*", 1)}
+	}
+	seen := make(map[string]bool)
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(c.resolve(pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid locale pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match locale pattern %q in %s", pattern, c.Path)
+		}
+		for _, p := range matches {
+			if strings.Contains(filepath.Base(p), ".backup.") || seen[p] {
+				continue
+			}
+			seen[p] = true
+			files = append(files, p)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Language returns the {lang} part of a locale path matching the layout, or
+// "" when there is no layout or the path does not match it.
+func (c *Config) Language(path string) string {
+	if c.layoutRe == nil {
+		return ""
+	}
+	dir, err := filepath.Abs(c.Dir())
+	if err != nil {
+		return ""
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(dir, abs)
+	if err != nil {
+		return ""
+	}
+	if m := c.layoutRe.FindStringSubmatch(filepath.ToSlash(rel)); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// ExtractPaths returns the configured project paths to scan.
+func (c *Config) ExtractPaths() []string {
+	paths := make([]string, 0, len(c.Extract.Paths))
+	for _, p := range c.Extract.Paths {
+		paths = append(paths, c.resolve(p))
+	}
+	return paths
+}
+
+// Ignored reports whether key matches an ignore pattern.
+func (c *Config) Ignored(key string) bool {
+	return matchKeyPattern(key, c.Ignore)
+}
+
+// RuleEnabled reports whether check reports rule.
+func (c *Config) RuleEnabled(rule string) bool {
+	for _, r := range c.Check.Disable {
+		if r == rule {
+			return false
+		}
+	}
+	return true
+}
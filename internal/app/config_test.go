@@ -0,0 +1,175 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeConfigFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFindConfigWalksUp(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFiles(t, root, map[string]string{
+		".i18ntool.json":  `{}`,
+		".i18ntool.yaml":  "",
+		"web/src/app.vue": "",
+	})
+
+	found, err := FindConfig(filepath.Join(root, "web", "src"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, ".i18ntool.yaml"); found != want {
+		t.Fatalf("FindConfig = %q, want %q", found, want)
+	}
+	if found, err := ConfigIn(filepath.Join(root, "web", "src")); err != nil || found != "" {
+		t.Fatalf("ConfigIn walked up: %q, %v", found, err)
+	}
+
+	empty := t.TempDir()
+	if found, err := FindConfig(empty); err != nil || (found != "" && strings.HasPrefix(found, empty)) {
+		t.Fatalf("expected no config below %s, got %q, %v", empty, found, err)
+	}
+}
+
+func TestLoadConfigYAMLAndJSON(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFiles(t, root, map[string]string{
+		".i18ntool.yaml": `
+locales: ["locales/*.json"]
+layout: locales/{lang}.json
+source: en
+format: sarif
+ignore: ["status.*"]
+extract:
+  paths: [src]
+  presets: [vue-i18n]
+  go_funcs: ["Tr:1"]
+check:
+  min_completion: 90
+  disable: [plural-unused]
+`,
+		"project.json": `{"source": "en", "check": {"min_completion": 90, "disable": ["plural-unused"]}, "extract": {"go_funcs": ["Tr:1"]}}`,
+	})
+
+	c, err := LoadConfig(filepath.Join(root, ".i18ntool.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Source != "en" || c.Format != FormatSARIF || c.Check.MinCompletion == nil || *c.Check.MinCompletion != 90 {
+		t.Fatalf("unexpected config %+v", c)
+	}
+	if !reflect.DeepEqual(c.Extract.GoFuncs, []string{"Tr:1"}) || !reflect.DeepEqual(c.Extract.Presets, []string{"vue-i18n"}) {
+		t.Fatalf("unexpected extract settings %+v", c.Extract)
+	}
+	if got := c.ExtractPaths(); !reflect.DeepEqual(got, []string{filepath.Join(root, "src")}) {
+		t.Fatalf("ExtractPaths = %v", got)
+	}
+	if !c.Ignored("status.active") || c.Ignored("menu.save") {
+		t.Fatalf("ignore patterns not applied")
+	}
+	if c.RuleEnabled(RulePluralUnused) || !c.RuleEnabled(RulePluralMissing) {
+		t.Fatalf("check.disable not applied")
+	}
+
+	j, err := LoadConfig(filepath.Join(root, "project.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if j.Source != "en" || j.RuleEnabled(RulePluralUnused) || !reflect.DeepEqual(j.Extract.GoFuncs, []string{"Tr:1"}) {
+		t.Fatalf("unexpected JSON config %+v", j)
+	}
+}
+
+func TestLoadConfigRejectsInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown field":      "locale: [en.json]\n",
+		"unknown format":     "format: html\n",
+		"unknown rule":       "check:\n  disable: [spelling]\n",
+		"layout":             "layout: locales/en.json\n",
+		"ignore pattern":     "ignore: ['[']\n",
+		"min without source": "check:\n  min_completion: 80\n",
+		"min out of range":   "source: en\ncheck:\n  min_completion: 120\n",
+	} {
+		path := filepath.Join(t.TempDir(), ".i18ntool.yaml")
+		writeConfigFiles(t, filepath.Dir(path), map[string]string{".i18ntool.yaml": content})
+		if _, err := LoadConfig(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestConfigLocaleFiles(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFiles(t, root, map[string]string{
+		".i18ntool.yaml":                     "layout: locales/{lang}/messages.json\n",
+		"locales/en/messages.json":           "{}",
+		"locales/de-AT/messages.json":        "{}",
+		"locales/de-AT/other.json":           "{}",
+		"extra/fr.json":                      "{}",
+		"extra/fr.json.backup.20240101-0000": "{}",
+		"extra/fr.backup.20240101.json":      "{}",
+	})
+	c, err := LoadConfig(filepath.Join(root, ".i18ntool.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := c.LocaleFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join(root, "locales", "de-AT", "messages.json"),
+		filepath.Join(root, "locales", "en", "messages.json"),
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Fatalf("LocaleFiles from layout = %v, want %v", files, want)
+	}
+	if got := c.Language(files[0]); got != "de-AT" {
+		t.Fatalf("Language(%s) = %q, want de-AT", files[0], got)
+	}
+	if got := c.Language(filepath.Join(root, "locales", "de-AT", "other.json")); got != "" {
+		t.Fatalf("expected no language outside the layout, got %q", got)
+	}
+
+	c.Locales = []string{"extra/*.json", "locales/en/messages.json"}
+	files, err = c.LocaleFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []string{filepath.Join(root, "extra", "fr.json"), filepath.Join(root, "locales", "en", "messages.json")}
+	if !reflect.DeepEqual(files, want) {
+		t.Fatalf("LocaleFiles = %v, want %v", files, want)
+	}
+
+	c.Locales = []string{"missing/*.json"}
+	if _, err := c.LocaleFiles(); err == nil {
+		t.Fatalf("expected an error for a pattern without matches")
+	}
+}
+
+func TestZeroConfig(t *testing.T) {
+	var c Config
+	files, err := c.LocaleFiles()
+	if err != nil || len(files) != 0 {
+		t.Fatalf("zero config LocaleFiles = %v, %v", files, err)
+	}
+	if c.Language("locales/en.json") != "" || c.Ignored("any") || !c.RuleEnabled(RuleMissing) || len(c.ExtractPaths()) != 0 {
+		t.Fatalf("zero config should configure nothing")
+	}
+}
@@ -0,0 +1,462 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// jsonNode is a JSON value that remembers the bytes it was parsed from, so
+// values that are not modified are written back byte-identical.
+type jsonNode struct {
+	raw    []byte      // original encoding; nil for new or modified values
+	object *jsonObject // set for objects
+	value  interface{} // decoded value of non-objects (numbers as json.Number)
+}
+
+// jsonObject keeps object members in file order. tail is the whitespace
+// before the closing brace, nil for objects built in memory.
+type jsonObject struct {
+	members []*jsonMember
+	tail    []byte
+}
+
+// jsonMember is a key/value pair of an object. lead (the whitespace before the
+// key, including blank lines) and sep (the colon with its spacing) are kept so
+// re-encoding an object does not reformat its untouched members. offset is the
+// byte position of the key in the parsed file, or -1 for members added later.
+type jsonMember struct {
+	key    string
+	rawKey []byte
+	lead   []byte
+	sep    []byte
+	offset int
+	node   *jsonNode
+}
+
+// jsonDocument is an order-preserving JSON translation file. Formatting is
+// detected on parse and reused for every object that has to be re-encoded.
+// content is the parsed file, used to turn member offsets into positions.
+type jsonDocument struct {
+	root            *jsonNode
+	indent          string
+	newline         string
+	trailingNewline bool
+	content         []byte
+}
+
+// newDocument returns an empty document using the tool's default formatting.
+func newDocument() *jsonDocument {
+	return &jsonDocument{root: &jsonNode{object: &jsonObject{}}, indent: "  ", newline: "\n"}
+}
+
+// parseDocument parses content into an order-preserving document.
+func parseDocument(content []byte) (*jsonDocument, error) {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+	start := bytes.IndexByte(content, '{')
+	root, err := parseNode(trimmed, start)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &jsonDocument{root: root, indent: "  ", newline: "\n", content: content}
+	if bytes.Contains(content, []byte("\r\n")) {
+		doc.newline = "\r\n"
+	}
+	doc.trailingNewline = bytes.HasSuffix(content, []byte("\n"))
+	if indent, ok := detectIndent(content); ok {
+		doc.indent = indent
+	}
+	return doc, nil
+}
+
+// detectIndent returns the leading whitespace of the first indented line.
+func detectIndent(content []byte) (string, bool) {
+	for _, line := range strings.Split(string(content), "\n")[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || len(trimmed) == len(line) {
+			continue
+		}
+		return line[:len(line)-len(trimmed)], true
+	}
+	return "", false
+}
+
+// parseNode parses one JSON value; base is its offset in the whole file.
+func parseNode(raw []byte, base int) (*jsonNode, error) {
+	if len(raw) == 0 || raw[0] != '{' {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		return &jsonNode{raw: raw, value: v}, nil
+	}
+
+	node := &jsonNode{raw: raw, object: &jsonObject{}}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil { // opening brace
+		return nil, err
+	}
+	// More skips whitespace, so leads and the tail are cut from prevEnd (the
+	// end of the opening brace or the previous value) instead
+	prevEnd := 1
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("offset %d: expected object key", base+prevEnd)
+		}
+		after := int(dec.InputOffset())
+		seg := raw[prevEnd:after]
+		if i := bytes.IndexByte(seg, ','); i >= 0 && i < bytes.IndexByte(seg, '"') {
+			seg = seg[i+1:]
+		}
+		rawKey := bytes.TrimLeft(seg, " \t\r\n")
+		lead := seg[:len(seg)-len(rawKey)]
+		keyOffset := base + after - len(rawKey)
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("offset %d: %w", keyOffset, err)
+		}
+		valueEnd := int(dec.InputOffset())
+		valueStart := valueEnd - len(value)
+		child, err := parseNode(value, base+valueStart)
+		if err != nil {
+			return nil, err
+		}
+		node.object.members = append(node.object.members, &jsonMember{
+			key:    key,
+			rawKey: append([]byte(nil), rawKey...),
+			lead:   append([]byte{}, lead...), // non-nil even when empty, see writeNode
+			sep:    append([]byte(nil), raw[after:valueStart]...),
+			offset: keyOffset,
+			node:   child,
+		})
+		prevEnd = valueEnd
+	}
+	if _, err := dec.Token(); err != nil { // closing brace
+		return nil, err
+	}
+	if end := int(dec.InputOffset()); end != len(raw) {
+		return nil, fmt.Errorf("offset %d: unexpected content after the closing brace", base+end)
+	}
+	node.object.tail = append([]byte{}, raw[prevEnd:len(raw)-1]...)
+	return node, nil
+}
+
+// nodeFromValue builds a node for a decoded Go value; map keys are sorted.
+func nodeFromValue(v interface{}) *jsonNode {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return &jsonNode{value: v}
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	obj := &jsonObject{}
+	for _, key := range keys {
+		obj.members = append(obj.members, &jsonMember{key: key, offset: -1, node: nodeFromValue(m[key])})
+	}
+	return &jsonNode{object: obj}
+}
+
+// stringNode returns a new string leaf.
+func stringNode(s string) *jsonNode {
+	return &jsonNode{value: s}
+}
+
+// toValue converts the node back into the generic form used by flattenKeys.
+func (n *jsonNode) toValue() interface{} {
+	if n.object == nil {
+		return n.value
+	}
+	m := make(map[string]interface{}, len(n.object.members))
+	for _, member := range n.object.members {
+		m[member.key] = member.node.toValue()
+	}
+	return m
+}
+
+// toMap returns the document content as a nested map.
+func (d *jsonDocument) toMap() map[string]interface{} {
+	return d.root.toValue().(map[string]interface{})
+}
+
+// clone deep-copies a node for use at another position. Objects lose their
+// raw bytes because their indentation depends on the depth they are written at.
+func (n *jsonNode) clone() *jsonNode {
+	if n.object == nil {
+		return &jsonNode{raw: n.raw, value: n.value}
+	}
+	obj := &jsonObject{}
+	for _, m := range n.object.members {
+		obj.members = append(obj.members, &jsonMember{key: m.key, rawKey: m.rawKey, sep: m.sep, offset: -1, node: m.node.clone()})
+	}
+	return &jsonNode{object: obj}
+}
+
+// get returns the member node for key; with duplicate keys the last one wins, as in encoding/json.
+func (o *jsonObject) get(key string) *jsonNode {
+	for i := len(o.members) - 1; i >= 0; i-- {
+		if o.members[i].key == key {
+			return o.members[i].node
+		}
+	}
+	return nil
+}
+
+// sorted reports whether the member keys are in ascending order.
+func (o *jsonObject) sorted() bool {
+	return sort.SliceIsSorted(o.members, func(i, j int) bool { return o.members[i].key < o.members[j].key })
+}
+
+// insert adds a member, keeping alphabetical order when the object is already
+// sorted and appending otherwise. The new member copies the whitespace of its
+// siblings so it follows the object's layout.
+func (o *jsonObject) insert(key string, n *jsonNode) {
+	m := &jsonMember{key: key, offset: -1, node: n, lead: o.memberLead()}
+	i := len(o.members)
+	if o.sorted() {
+		i = sort.Search(len(o.members), func(i int) bool { return o.members[i].key >= key })
+	}
+	o.members = append(o.members, nil)
+	copy(o.members[i+1:], o.members[i:])
+	o.members[i] = m
+	if i == 0 && len(o.members) > 1 {
+		// the first member's lead follows the opening brace, not a comma
+		m.lead, o.members[1].lead = o.members[1].lead, m.lead
+	}
+}
+
+// memberLead returns the whitespace used before members that follow a comma,
+// without blank lines, or nil when the object has no parsed layout.
+func (o *jsonObject) memberLead() []byte {
+	for i := len(o.members) - 1; i >= 0; i-- {
+		lead := o.members[i].lead
+		if lead == nil {
+			continue
+		}
+		if j := bytes.LastIndexByte(lead, '\n'); j >= 0 {
+			if j > 0 && lead[j-1] == '\r' {
+				j--
+			}
+			return append([]byte{}, lead[j:]...)
+		}
+		if i > 0 {
+			return append([]byte{}, lead...)
+		}
+		return []byte(" ")
+	}
+	return nil
+}
+
+// remove deletes every member named key and reports whether one existed.
+// A member moving into first place takes over the lead of the old first one.
+func (o *jsonObject) remove(key string) bool {
+	if len(o.members) == 0 {
+		return false
+	}
+	firstLead := o.members[0].lead
+	kept := o.members[:0]
+	removed := false
+	for _, m := range o.members {
+		if m.key == key {
+			removed = true
+			continue
+		}
+		kept = append(kept, m)
+	}
+	o.members = kept
+	if removed && len(kept) > 0 && kept[0].lead != nil {
+		kept[0].lead = firstLead
+	}
+	return removed
+}
+
+// resolvePath splits a dotted key into the member names leading to its node.
+// Member names may contain dots themselves ("usage.title" at the top level),
+// so an exact member match is preferred over descending into an object.
+func resolvePath(root *jsonNode, key string) []string {
+	if root.object == nil {
+		return nil
+	}
+	if root.object.get(key) != nil {
+		return []string{key}
+	}
+	for i := len(root.object.members) - 1; i >= 0; i-- {
+		m := root.object.members[i]
+		if m.node.object == nil || !strings.HasPrefix(key, m.key+".") || root.object.get(m.key) != m.node {
+			continue
+		}
+		if rest := resolvePath(m.node, key[len(m.key)+1:]); rest != nil {
+			return append([]string{m.key}, rest...)
+		}
+	}
+	return nil
+}
+
+// lookupNode returns the node at a dotted key, or nil.
+func lookupNode(root *jsonNode, key string) *jsonNode {
+	path := resolvePath(root, key)
+	if path == nil {
+		return nil
+	}
+	current := root
+	for _, part := range path {
+		current = current.object.get(part)
+	}
+	return current
+}
+
+// replaceNode swaps the value at an existing dotted key.
+func replaceNode(root *jsonNode, key string, n *jsonNode) error {
+	path := resolvePath(root, key)
+	if path == nil {
+		return fmt.Errorf("key '%s' does not exist", key)
+	}
+	current := root
+	for i, part := range path {
+		current.raw = nil
+		if i == len(path)-1 {
+			for j := len(current.object.members) - 1; j >= 0; j-- {
+				if m := current.object.members[j]; m.key == part {
+					m.node = n
+					break
+				}
+			}
+			break
+		}
+		current = current.object.get(part)
+	}
+	return nil
+}
+
+// removeNode deletes the value at a dotted key and removes parent objects
+// left empty. It reports whether the key existed.
+func removeNode(root *jsonNode, key string) bool {
+	parts := resolvePath(root, key)
+	if parts == nil {
+		return false
+	}
+	path := []*jsonNode{root}
+	current := root
+	for _, part := range parts[:len(parts)-1] {
+		current = current.object.get(part)
+		path = append(path, current)
+	}
+
+	for _, n := range path {
+		n.raw = nil
+	}
+	current.object.remove(parts[len(parts)-1])
+	for i := len(path) - 1; i > 0 && len(path[i].object.members) == 0; i-- {
+		path[i-1].object.remove(parts[i-1])
+	}
+	return true
+}
+
+// sortNode recursively sorts object members and reports whether anything moved.
+func sortNode(n *jsonNode) bool {
+	if n.object == nil {
+		return false
+	}
+	changed := false
+	for _, m := range n.object.members {
+		if sortNode(m.node) {
+			changed = true
+		}
+	}
+	if !n.object.sorted() {
+		sort.SliceStable(n.object.members, func(i, j int) bool { return n.object.members[i].key < n.object.members[j].key })
+		// the original line breaks and blank lines belong to the old order
+		for _, m := range n.object.members {
+			m.lead = nil
+		}
+		n.object.tail = nil
+		changed = true
+	}
+	if changed {
+		n.raw = nil
+	}
+	return changed
+}
+
+// Bytes encodes the document. Nodes that still hold their original bytes are
+// copied verbatim; everything else uses the detected indentation.
+func (d *jsonDocument) Bytes() []byte {
+	var b bytes.Buffer
+	d.writeNode(&b, d.root, 0)
+	if d.trailingNewline {
+		b.WriteString(d.newline)
+	}
+	return b.Bytes()
+}
+
+func (d *jsonDocument) writeNode(b *bytes.Buffer, n *jsonNode, depth int) {
+	if n.raw != nil {
+		b.Write(n.raw)
+		return
+	}
+	if n.object == nil {
+		b.Write(encodeJSONValue(n.value))
+		return
+	}
+	if len(n.object.members) == 0 {
+		b.WriteString("{}")
+		return
+	}
+
+	b.WriteString("{")
+	for i, m := range n.object.members {
+		if m.lead != nil {
+			b.Write(m.lead)
+		} else {
+			b.WriteString(d.newline + strings.Repeat(d.indent, depth+1))
+		}
+		if m.rawKey != nil {
+			b.Write(m.rawKey)
+		} else {
+			b.Write(encodeJSONValue(m.key))
+		}
+		if m.sep != nil {
+			b.Write(m.sep)
+		} else {
+			b.WriteString(": ")
+		}
+		d.writeNode(b, m.node, depth+1)
+		if i < len(n.object.members)-1 {
+			b.WriteString(",")
+		}
+	}
+	if n.object.tail != nil {
+		b.Write(n.object.tail)
+	} else {
+		b.WriteString(d.newline + strings.Repeat(d.indent, depth))
+	}
+	b.WriteString("}")
+}
+
+// encodeJSONValue encodes v without escaping <, > and & as encoding/json does by default.
+func encodeJSONValue(v interface{}) []byte {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return []byte("null")
+	}
+	return bytes.TrimRight(b.Bytes(), "\n")
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDocument_RoundTripUnchanged(t *testing.T) {
+	inputs := []string{
+		"{\n  \"b\": \"x\",\n\n  \"a\": {\"c\": 1}\n}",
+		"{\r\n    \"z\": \"<tag> & co\",\r\n    \"n\": 12345678901234567890\r\n}\r\n",
+		"{}",
+	}
+	for _, in := range inputs {
+		doc, err := parseDocument([]byte(in))
+		if err != nil {
+			t.Fatalf("parseDocument(%q): %v", in, err)
+		}
+		if got := string(doc.Bytes()); got != in {
+			t.Fatalf("round trip changed the file\nwant: %q\ngot:  %q", in, got)
+		}
+	}
+}
+
+func TestDocument_RejectsTrailingContent(t *testing.T) {
+	for _, in := range []string{"{\"a\": \"1\"} garbage {", "{} {}", "{\"a\": {}}}"} {
+		if _, err := parseDocument([]byte(in)); err == nil {
+			t.Errorf("parseDocument(%q): expected an error", in)
+		}
+	}
+	if _, err := parseDocument([]byte("{\"a\": \"1\"}\n\n")); err != nil {
+		t.Fatalf("trailing whitespace should be accepted: %v", err)
+	}
+}
+
+func TestAddTranslation_PreservesFormatting(t *testing.T) {
+	in := "{\n" +
+		"    \"usage\": \"Usage: <file.json>\",\n" +
+		"\n" +
+		"    \"limits\": {\n" +
+		"        \"max\": 12345678901234567890,\n" +
+		"        \"title\": \"A & B\"\n" +
+		"    }\n" +
+		"}\n"
+	path := filepath.Join(t.TempDir(), "en.json")
+	if err := os.WriteFile(path, []byte(in), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tm := &TranslationManager{}
+	if err := tm.AddTranslation(path, "limits.min", "<none>"); err != nil {
+		t.Fatalf("AddTranslation: %v", err)
+	}
+
+	got, _ := os.ReadFile(path)
+	want := "{\n" +
+		"    \"usage\": \"Usage: <file.json>\",\n" +
+		"\n" +
+		"    \"limits\": {\n" +
+		"        \"max\": 12345678901234567890,\n" +
+		"        \"min\": \"<none>\",\n" +
+		"        \"title\": \"A & B\"\n" +
+		"    }\n" +
+		"}\n"
+	if string(got) != want {
+		t.Fatalf("unexpected file content\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestSortAndSave_OnlyReordersChangedObjects(t *testing.T) {
+	in := "{\n  \"b\": {\"y\": 1, \"x\": 2},\n  \"a\": {\"k\": \"v\"}\n}"
+	path := filepath.Join(t.TempDir(), "de.json")
+	if err := os.WriteFile(path, []byte(in), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tm, err := NewTranslationManager(map[string]string{"de": path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.SortAndSave(false); err != nil {
+		t.Fatalf("SortAndSave: %v", err)
+	}
+
+	got, _ := os.ReadFile(path)
+	want := "{\n  \"a\": {\"k\": \"v\"},\n  \"b\": {\n    \"x\": 2,\n    \"y\": 1\n  }\n}"
+	if string(got) != want {
+		t.Fatalf("unexpected sorted content\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestRemoveNode_CollapsesEmptyParents(t *testing.T) {
+	doc, err := parseDocument([]byte(`{"a": {"b": {"c": "x"}}, "d": "y"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !removeNode(doc.root, "a.b.c") {
+		t.Fatalf("expected a.b.c to be removed")
+	}
+	if got := string(doc.Bytes()); !strings.Contains(got, `"d": "y"`) || strings.Contains(got, `"a"`) {
+		t.Fatalf("expected empty parents to be removed, got %s", got)
+	}
+}
+
+func TestDocument_SingleLineObjectsKeepLayout(t *testing.T) {
+	doc, err := parseDocument([]byte(`{"b": {"y": 1}, "c": 2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (&TranslationManager{}).addNestedKey(doc.root, "a", stringNode("x")); err != nil {
+		t.Fatal(err)
+	}
+	removeNode(doc.root, "b.y")
+	if got, want := string(doc.Bytes()), `{"a": "x", "c": 2}`; got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mlechner911/i18ntool/internal/simpletrans"
+)
+
+// DuplicateKey is an object key that occurs more than once in one file.
+// encoding/json keeps only the last value, so earlier ones are silently lost.
+type DuplicateKey struct {
+	Language    string `json:"language,omitempty"`
+	File        string `json:"file"`
+	Key         string `json:"key"`
+	Line        int    `json:"line"`
+	Column      int    `json:"column"`
+	FirstLine   int    `json:"firstLine"`
+	FirstColumn int    `json:"firstColumn"`
+}
+
+// duplicateKeys returns every repeated member of the parsed document, located
+// at the repeated occurrence and pointing back to the first one.
+func (d *jsonDocument) duplicateKeys() []DuplicateKey {
+	// the document parsed, so the content is valid JSON
+	found, _ := simpletrans.FindDuplicateKeys(d.content)
+	dups := make([]DuplicateKey, 0, len(found))
+	for _, e := range found {
+		dups = append(dups, DuplicateKey{Key: e.Key, Line: e.Line, Column: e.Column, FirstLine: e.FirstLine, FirstColumn: e.FirstColumn})
+	}
+	return dups
+}
+
+// DuplicateKeys returns the duplicate keys found while loading each language.
+func (tm *TranslationManager) DuplicateKeys() []DuplicateKey {
+	var dups []DuplicateKey
+	for _, lang := range tm.Languages {
+		doc := tm.docs[lang]
+		if doc == nil {
+			continue
+		}
+		for _, dup := range doc.duplicateKeys() {
+			dup.Language = lang
+			dup.File = tm.files[lang]
+			dups = append(dups, dup)
+		}
+	}
+	return dups
+}
+
+// LintFile validates a translation file on the token level. Syntax errors and
+// duplicate keys are returned as issues with line and column; the error is
+// only set when the file cannot be read.
+func LintFile(path string) ([]Issue, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(content, &v); err != nil {
+		issue := Issue{Rule: RuleInvalidJSON, Level: "error", File: path, Message: err.Error()}
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			// Offset counts the bytes read including the offending one
+			issue.Line, issue.Column = lineColumn(content, max(int(syntaxErr.Offset)-1, 0))
+		}
+		return []Issue{issue}, nil
+	}
+	doc, err := parseDocument(content)
+	if err != nil {
+		return []Issue{{Rule: RuleInvalidJSON, Level: "error", File: path, Message: err.Error()}}, nil
+	}
+
+	dups := doc.duplicateKeys()
+	for i := range dups {
+		dups[i].File = path
+	}
+	return DuplicateIssues(dups), nil
+}
+
+// DuplicateIssues converts duplicate keys into report issues located at the
+// repeated occurrence.
+func DuplicateIssues(dups []DuplicateKey) []Issue {
+	issues := make([]Issue, 0, len(dups))
+	for _, dup := range dups {
+		issues = append(issues, Issue{
+			Rule:     RuleDuplicateKey,
+			Level:    "error",
+			Key:      dup.Key,
+			Language: dup.Language,
+			File:     dup.File,
+			Line:     dup.Line,
+			Column:   dup.Column,
+			Message:  fmt.Sprintf("duplicate key %q (first defined at line %d, column %d); only the last value is kept", dup.Key, dup.FirstLine, dup.FirstColumn),
+		})
+	}
+	return issues
+}
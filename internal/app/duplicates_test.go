@@ -0,0 +1,45 @@
+package app
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDuplicateKeys(t *testing.T) {
+	dir := t.TempDir()
+	en := writeLocale(t, dir, "en.json", "{\n  \"common\": {\n    \"save\": \"Save\"\n  }\n}\n")
+	de := writeLocale(t, dir, "de.json", "{\n  \"common\": {\n    \"save\": \"Speichern\",\n    \"save\": \"Sichern\"\n  },\n  \"x\": {\"save\": \"y\"}\n}\n")
+	tm, err := NewTranslationManager(map[string]string{"en": en, "de": de})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []DuplicateKey{{Language: "de", File: de, Key: "common.save", Line: 4, Column: 5, FirstLine: 3, FirstColumn: 5}}
+	if got := tm.DuplicateKeys(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestLintFile(t *testing.T) {
+	dir := t.TempDir()
+	good := writeLocale(t, dir, "good.json", `{"a": "x", "b": {"a": "y"}}`)
+	dup := writeLocale(t, dir, "dup.json", "{\"a\": \"x\",\n \"a\": \"y\"}")
+	bad := writeLocale(t, dir, "bad.json", "{\n  \"a\": }")
+
+	issues, err := LintFile(good)
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("expected no issues, got %v, %v", issues, err)
+	}
+	issues, err = LintFile(dup)
+	if err != nil || len(issues) != 1 || issues[0].Rule != RuleDuplicateKey || issues[0].Line != 2 || issues[0].Column != 2 {
+		t.Fatalf("unexpected duplicate issues %+v, %v", issues, err)
+	}
+	issues, err = LintFile(bad)
+	if err != nil || len(issues) != 1 || issues[0].Rule != RuleInvalidJSON || issues[0].Line != 2 || issues[0].Column != 8 {
+		t.Fatalf("unexpected syntax issues %+v, %v", issues, err)
+	}
+	if _, err := LintFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
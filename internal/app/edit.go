@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// KeyEdit reports what a multi-language edit changed in one file. Keys lists
+// the affected leaf keys and is empty for files that were left unchanged.
+type KeyEdit struct {
+	Language string
+	File     string
+	Keys     []string
+}
+
+// leafKeys returns the flattened keys of lang equal to key or below it.
+func (tm *TranslationManager) leafKeys(lang, key string) []string {
+	var keys []string
+	for k := range tm.flattenKeys("", tm.data[lang]) {
+		if k == key || strings.HasPrefix(k, key+".") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DeleteKey removes a key, or every key below a prefix, from all loaded
+// languages. Objects left empty are removed and each changed file is backed
+// up once. It fails without writing when no language has the key.
+func (tm *TranslationManager) DeleteKey(key string) ([]KeyEdit, error) {
+	edits := make([]KeyEdit, 0, len(tm.Languages))
+	found := false
+	for _, lang := range tm.Languages {
+		keys := tm.leafKeys(lang, key)
+		found = found || len(keys) > 0
+		edits = append(edits, KeyEdit{Language: lang, File: tm.files[lang], Keys: keys})
+	}
+	if !found {
+		return nil, fmt.Errorf("key '%s' does not exist", key)
+	}
+
+	for _, edit := range edits {
+		if len(edit.Keys) == 0 {
+			continue
+		}
+		root := tm.docs[edit.Language].root
+		removeNode(root, key)
+		for _, k := range edit.Keys {
+			removeNode(root, k)
+		}
+		if err := tm.saveLanguage(edit.Language, true); err != nil {
+			return nil, err
+		}
+	}
+	return edits, nil
+}
+
+// CopyKey copies the value or subtree at src to dst in every language that
+// has src. Like RenameKey it refuses an existing dst and writes nothing
+// unless the copy succeeds everywhere. An src without values, such as an
+// empty object, is an error.
+func (tm *TranslationManager) CopyKey(src, dst string) ([]KeyEdit, error) {
+	if src == dst || strings.HasPrefix(dst, src+".") {
+		return nil, fmt.Errorf("cannot copy '%s' into itself ('%s')", src, dst)
+	}
+
+	edits := make([]KeyEdit, 0, len(tm.Languages))
+	exists, found := false, false
+	for _, lang := range tm.Languages {
+		root := tm.docs[lang].root
+		if tm.keyExists(dst, root) {
+			return nil, fmt.Errorf("key '%s' already exists in %s", dst, tm.files[lang])
+		}
+		edit := KeyEdit{Language: lang, File: tm.files[lang]}
+		if node := lookupNode(root, src); node != nil {
+			scratch := root.clone()
+			if err := tm.addNestedKey(scratch, dst, node.clone()); err != nil {
+				return nil, fmt.Errorf("%s: %w", tm.files[lang], err)
+			}
+			for _, k := range tm.leafKeys(lang, src) {
+				edit.Keys = append(edit.Keys, dst+strings.TrimPrefix(k, src))
+			}
+			exists = true
+			found = found || len(edit.Keys) > 0
+		}
+		edits = append(edits, edit)
+	}
+	if !exists {
+		return nil, fmt.Errorf("key '%s' does not exist", src)
+	}
+	if !found {
+		return nil, fmt.Errorf("key '%s' has no values to copy", src)
+	}
+
+	for _, edit := range edits {
+		if len(edit.Keys) == 0 {
+			continue
+		}
+		root := tm.docs[edit.Language].root
+		if err := tm.addNestedKey(root, dst, lookupNode(root, src).clone()); err != nil {
+			return nil, fmt.Errorf("%s: %w", edit.File, err)
+		}
+		if err := tm.saveLanguage(edit.Language, true); err != nil {
+			return nil, err
+		}
+	}
+	return edits, nil
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func loadEditFixture(t *testing.T) (*TranslationManager, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	en := writeLocale(t, dir, "en.json", `{"menu": {"file": {"open": "Open", "save": "Save"}}, "menu.flat": "x", "title": "T"}`)
+	de := writeLocale(t, dir, "de.json", `{"title": "T"}`)
+	tm, err := NewTranslationManager(map[string]string{"en": en, "de": de})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tm, en, de
+}
+
+func TestDeleteKey_PrefixInEveryLocale(t *testing.T) {
+	tm, en, de := loadEditFixture(t)
+	edits, err := tm.DeleteKey("menu")
+	if err != nil {
+		t.Fatalf("DeleteKey: %v", err)
+	}
+	want := map[string][]string{"en": {"menu.file.open", "menu.file.save", "menu.flat"}, "de": nil}
+	for _, e := range edits {
+		if !reflect.DeepEqual(e.Keys, want[e.Language]) {
+			t.Errorf("%s: want %v, got %v", e.Language, want[e.Language], e.Keys)
+		}
+	}
+	if got, _ := os.ReadFile(en); string(got) != `{"title": "T"}` {
+		t.Fatalf("unexpected en: %s", got)
+	}
+	if backups, _ := filepath.Glob(de + ".backup.*"); len(backups) != 0 {
+		t.Fatalf("unchanged file must not be backed up: %v", backups)
+	}
+	if _, err := tm.DeleteKey("menu"); err == nil {
+		t.Fatalf("expected error when deleting a missing key")
+	}
+}
+
+func TestCopyKey(t *testing.T) {
+	tm, en, _ := loadEditFixture(t)
+	edits, err := tm.CopyKey("menu.file", "toolbar")
+	if err != nil {
+		t.Fatalf("CopyKey: %v", err)
+	}
+	if !reflect.DeepEqual(edits[1].Keys, []string{"toolbar.open", "toolbar.save"}) || edits[0].Keys != nil {
+		t.Fatalf("unexpected edits %+v", edits)
+	}
+	data, _ := readJSONFile(en)
+	flat := tm.flattenKeys("", data)
+	if flat["toolbar.open"] != "Open" || flat["menu.file.open"] != "Open" {
+		t.Fatalf("unexpected en content %v", flat)
+	}
+
+	if _, err := tm.CopyKey("title", "toolbar"); err == nil {
+		t.Fatalf("expected error for an existing target")
+	}
+	if _, err := tm.CopyKey("menu", "menu.copy"); err == nil {
+		t.Fatalf("expected error when copying into itself")
+	}
+}
+
+func TestCopyKey_EmptyObject(t *testing.T) {
+	dir := t.TempDir()
+	en := writeLocale(t, dir, "en.json", `{"empty": {}}`)
+	tm, err := NewTranslationManager(map[string]string{"en": en})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tm.CopyKey("empty", "copy"); err == nil {
+		t.Fatal("expected an error for an object without values")
+	}
+	if data, _ := os.ReadFile(en); string(data) != `{"empty": {}}` {
+		t.Fatalf("en.json was written: %s", data)
+	}
+}
@@ -0,0 +1,198 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EmbedOptions configures GenerateEmbed.
+type EmbedOptions struct {
+	Package string   // package of the generated file; defaults to the output directory's name
+	Var     string   // name of the generated variable; defaults to EmbeddedTranslations
+	Out     string   // path of the generated file; embed paths are relative to its directory
+	FS      bool     // emit a //go:embed embed.FS and loader instead of a literal map
+	Inputs  []string // locale files or glob patterns; the file name without .json is the locale
+}
+
+// embedFile is one input of GenerateEmbed.
+type embedFile struct {
+	locale string
+	path   string
+	data   map[string]interface{}
+}
+
+// GenerateEmbed returns the Go source embedding the input locale files. The
+// default form is a literal map[string]map[string]string of flattened keys,
+// as used by simpletrans.EmbeddedTranslations; non-string values are left
+// out. With FS the files are embedded unchanged together with a loader. The
+// output is gofmt'ed and depends only on the options and file contents.
+// GenerateEmbed also returns the embedded locales.
+func GenerateEmbed(opts EmbedOptions) ([]byte, []string, error) {
+	if opts.Var == "" {
+		opts.Var = "EmbeddedTranslations"
+	}
+	pkg, err := packageName(opts.Package, opts.Out)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts.Package = pkg
+	if !token.IsIdentifier(opts.Var) {
+		return nil, nil, fmt.Errorf("invalid variable name %q", opts.Var)
+	}
+	files, err := embedInputs(opts.Inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by i18n-manager embed; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", opts.Package)
+	if opts.FS {
+		err = writeEmbedFS(&buf, opts, files)
+	} else {
+		writeEmbedMap(&buf, opts, files)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("formatting generated source: %w", err)
+	}
+	locales := make([]string, len(files))
+	for i, f := range files {
+		locales[i] = f.locale
+	}
+	return src, locales, nil
+}
+
+// packageName returns pkg, or the name of the directory out is in when pkg
+// is empty, after checking that it is a valid package name.
+func packageName(pkg, out string) (string, error) {
+	if pkg == "" {
+		abs, err := filepath.Abs(out)
+		if err != nil {
+			return "", err
+		}
+		pkg = filepath.Base(filepath.Dir(abs))
+	}
+	if !token.IsIdentifier(pkg) {
+		return "", fmt.Errorf("invalid package name %q", pkg)
+	}
+	return pkg, nil
+}
+
+// embedInputs expands the patterns, skips sort backups and loads the files
+// sorted by locale. Two files with the same locale are an error.
+func embedInputs(patterns []string) ([]embedFile, error) {
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("no input files")
+	}
+	byLocale := make(map[string]embedFile)
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %s", pattern)
+		}
+		for _, path := range matches {
+			if strings.Contains(filepath.Base(path), ".backup.") {
+				continue
+			}
+			locale := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+			if prev, ok := byLocale[locale]; ok {
+				if filepath.Clean(prev.path) == filepath.Clean(path) {
+					continue
+				}
+				return nil, fmt.Errorf("locale %s is given twice: %s and %s", locale, prev.path, path)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", path, err)
+			}
+			var data map[string]interface{}
+			if err := json.Unmarshal(content, &data); err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", path, err)
+			}
+			byLocale[locale] = embedFile{locale: locale, path: path, data: data}
+		}
+	}
+	files := make([]embedFile, 0, len(byLocale))
+	for _, f := range byLocale {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].locale < files[j].locale })
+	return files, nil
+}
+
+func writeEmbedMap(buf *bytes.Buffer, opts EmbedOptions, files []embedFile) {
+	fmt.Fprintf(buf, "// %s holds the flattened translations of each locale.\n", opts.Var)
+	fmt.Fprintf(buf, "var %s = map[string]map[string]string{\n", opts.Var)
+	tm := &TranslationManager{}
+	for _, f := range files {
+		flat := tm.flattenKeys("", f.data)
+		keys := make([]string, 0, len(flat))
+		for k, v := range flat {
+			if _, ok := v.(string); ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(buf, "%s: {\n", strconv.Quote(f.locale))
+		for _, k := range keys {
+			fmt.Fprintf(buf, "%s: %s,\n", strconv.Quote(k), strconv.Quote(flat[k].(string)))
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+}
+
+func writeEmbedFS(buf *bytes.Buffer, opts EmbedOptions, files []embedFile) error {
+	outDir, err := filepath.Abs(filepath.Dir(opts.Out))
+	if err != nil {
+		return err
+	}
+	paths := make([]string, len(files))
+	for i, f := range files {
+		abs, err := filepath.Abs(f.path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(outDir, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("%s is outside %s; go:embed only reaches files in the output directory and below", f.path, outDir)
+		}
+		paths[i] = filepath.ToSlash(rel)
+	}
+	loader := "Load" + opts.Var
+	if first := opts.Var[:1]; strings.ToLower(first) == first {
+		loader = "load" + strings.ToUpper(first) + opts.Var[1:]
+	}
+
+	buf.WriteString("import (\n\"embed\"\n\"encoding/json\"\n\"fmt\"\n)\n\n")
+	fmt.Fprintf(buf, "// %s holds the translation files.\n//\n//go:embed %s\nvar %s embed.FS\n\n", opts.Var, strings.Join(paths, " "), opts.Var)
+	fmt.Fprintf(buf, "// %sFiles maps each locale to its file in %s.\n", opts.Var, opts.Var)
+	fmt.Fprintf(buf, "var %sFiles = map[string]string{\n", opts.Var)
+	for i, f := range files {
+		fmt.Fprintf(buf, "%s: %s,\n", strconv.Quote(f.locale), strconv.Quote(paths[i]))
+	}
+	buf.WriteString("}\n\n")
+	fmt.Fprintf(buf, "// %s decodes the embedded translations of locale.\n", loader)
+	fmt.Fprintf(buf, "func %s(locale string) (map[string]interface{}, error) {\n", loader)
+	fmt.Fprintf(buf, "name, ok := %sFiles[locale]\n", opts.Var)
+	buf.WriteString("if !ok {\nreturn nil, fmt.Errorf(\"no embedded translations for %q\", locale)\n}\n")
+	fmt.Fprintf(buf, "data, err := %s.ReadFile(name)\n", opts.Var)
+	buf.WriteString("if err != nil {\nreturn nil, err\n}\n")
+	buf.WriteString("var t map[string]interface{}\nif err := json.Unmarshal(data, &t); err != nil {\nreturn nil, fmt.Errorf(\"decode %s: %w\", name, err)\n}\nreturn t, nil\n}\n")
+	return nil
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateEmbedMap(t *testing.T) {
+	dir := t.TempDir()
+	writeLocale(t, dir, "en.json", `{"b": "B \"quoted\"", "a": {"y": "line\\n", "x": "X"}, "n": 3}`)
+	writeLocale(t, dir, "de.json", `{"a": {"x": "X-de"}}`)
+	writeLocale(t, dir, "en.json.backup.20240101", `{}`)
+
+	opts := EmbedOptions{Package: "locales", Out: filepath.Join(dir, "embedded.go"), Inputs: []string{filepath.Join(dir, "*.json*")}}
+	src, locales, err := GenerateEmbed(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(locales, []string{"de", "en"}) {
+		t.Fatalf("unexpected locales %v", locales)
+	}
+	want := `// Code generated by i18n-manager embed; DO NOT EDIT.
+
+package locales
+
+// EmbeddedTranslations holds the flattened translations of each locale.
+var EmbeddedTranslations = map[string]map[string]string{
+	"de": {
+		"a.x": "X-de",
+	},
+	"en": {
+		"a.x": "X",
+		"a.y": "line\\n",
+		"b":   "B \"quoted\"",
+	},
+}
+`
+	if string(src) != want {
+		t.Fatalf("unexpected source:\n%s", src)
+	}
+	again, _, _ := GenerateEmbed(opts)
+	if !bytes.Equal(src, again) {
+		t.Error("output is not deterministic")
+	}
+}
+
+func TestGenerateEmbedFS(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "locales")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeLocale(t, dir, "en.json", `{"a": "A"}`)
+	writeLocale(t, dir, "de.json", `{"a": "A-de"}`)
+
+	src, _, err := GenerateEmbed(EmbedOptions{Var: "localeFS", FS: true, Out: filepath.Join(dir, "gen.go"), Inputs: []string{filepath.Join(dir, "en.json"), filepath.Join(dir, "de.json")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"package locales\n",
+		"//go:embed de.json en.json\nvar localeFS embed.FS",
+		`"de": "de.json",`,
+		"func loadLocaleFS(locale string) (map[string]interface{}, error) {",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("missing %q in:\n%s", want, src)
+		}
+	}
+}
+
+func TestGenerateEmbedErrors(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "sub")
+	en := writeLocale(t, dir, "en.json", `{"a": "A"}`)
+	tests := []struct {
+		name string
+		opts EmbedOptions
+		want string
+	}{
+		{"no match", EmbedOptions{Package: "p", Inputs: []string{filepath.Join(dir, "*.yaml")}}, "no files match"},
+		{"bad var", EmbedOptions{Package: "p", Var: "my-var", Inputs: []string{en}}, "invalid variable name"},
+		{"outside", EmbedOptions{Package: "p", FS: true, Out: filepath.Join(sub, "gen.go"), Inputs: []string{en}}, "outside"},
+		{"invalid json", EmbedOptions{Package: "p", Inputs: []string{writeLocale(t, dir, "de.json", `{`)}}, "parsing"},
+	}
+	for _, tt := range tests {
+		if _, _, err := GenerateEmbed(tt.opts); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+		}
+	}
+}
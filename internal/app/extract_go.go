@@ -0,0 +1,188 @@
+package app
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// KeyRef is a translation key referenced from a source file.
+type KeyRef struct {
+	Key    string `json:"key"`
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// GoFunc names a Go translation function and the zero-based index of the
+// argument holding the key. Name is either a bare function or method name
+// ("T") or a qualified one ("i18n.T"). With Pkg set, Name is the function of
+// the package with that import path, however the file imports it.
+type GoFunc struct {
+	Name string
+	Pkg  string
+	Arg  int
+}
+
+// simpletransPkg is the import path of the simpletrans package.
+const simpletransPkg = "github.com/mlechner911/i18ntool/internal/simpletrans"
+
+// DefaultGoFuncs covers simpletrans.GetTranslation(t, key, ...), the
+// simpletrans functions taking the language before the key (GetPlural,
+// FormatMessage), the Bundle methods taking it after the tag and the
+// Localizer methods T and Plural. Localizer.Format is left out because the
+// bare name would also match time.Time.Format layouts; add it with
+// --go-func Format. The same goes for the Catalog methods, which take the key
+// first (--go-func GetPlural:1).
+var DefaultGoFuncs = []GoFunc{
+	{Name: "GetTranslation", Arg: 1},
+	{Name: "GetPlural", Pkg: simpletransPkg, Arg: 2},
+	{Name: "FormatMessage", Pkg: simpletransPkg, Arg: 2},
+	{Name: "GetPlural", Arg: 1},
+	{Name: "FormatMessage", Arg: 1},
+	{Name: "T", Arg: 0},
+	{Name: "Plural", Arg: 0},
+}
+
+// ParseGoFunc parses a "Name:N" specification where N is the 1-based
+// position of the key argument. Without ":N" the first argument is used.
+func ParseGoFunc(spec string) (GoFunc, error) {
+	name, pos, hasPos := strings.Cut(spec, ":")
+	if name == "" {
+		return GoFunc{}, fmt.Errorf("invalid Go function %q: missing name", spec)
+	}
+	if !hasPos {
+		return GoFunc{Name: name}, nil
+	}
+	n, err := strconv.Atoi(pos)
+	if err != nil || n < 1 {
+		return GoFunc{}, fmt.Errorf("invalid Go function %q: argument position must be a number >= 1", spec)
+	}
+	return GoFunc{Name: name, Arg: n - 1}, nil
+}
+
+// ExtractGoKeys parses Go source and returns the string literals passed as
+// key argument to one of funcs. Calls with a non-literal key are ignored.
+func ExtractGoKeys(path string, src []byte, funcs []GoFunc) ([]KeyRef, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	imports := goImportsOf(file)
+
+	var refs []KeyRef
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		for _, f := range goFuncsFor(call.Fun, funcs, imports) {
+			if f.Arg >= len(call.Args) {
+				continue
+			}
+			lit, ok := call.Args[f.Arg].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				continue
+			}
+			key, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				continue
+			}
+			// like the regex extractors, point at the key rather than its opening quote
+			pos := fset.Position(lit.Pos() + 1)
+			refs = append(refs, KeyRef{Key: key, File: path, Line: pos.Line, Column: pos.Column})
+		}
+		return true
+	})
+	return refs, nil
+}
+
+// goImports resolves the package names used in one Go file.
+type goImports struct {
+	pkg   string            // name in the package clause
+	names map[string]string // local import name -> import path
+	dot   map[string]bool   // dot-imported paths
+}
+
+func goImportsOf(file *ast.File) goImports {
+	imports := goImports{pkg: file.Name.Name, names: map[string]string{}, dot: map[string]bool{}}
+	for _, spec := range file.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		switch {
+		case spec.Name == nil:
+			imports.names[path.Base(p)] = p
+		case spec.Name.Name == ".":
+			imports.dot[p] = true
+		case spec.Name.Name != "_":
+			imports.names[spec.Name.Name] = p
+		}
+	}
+	return imports
+}
+
+// goFuncsFor returns the funcs naming the called expression. Package and
+// qualified names win over bare ones, so the simpletrans entry alone decides
+// where the key of simpletrans.GetPlural is, although the Bundle method entry
+// "GetPlural" matches it too.
+func goFuncsFor(fun ast.Expr, funcs []GoFunc, imports goImports) []GoFunc {
+	var bare, qualified []GoFunc
+	for _, f := range funcs {
+		switch {
+		case f.Pkg != "":
+			if matchGoPkgFunc(fun, f, imports) {
+				qualified = append(qualified, f)
+			}
+		case !matchGoFunc(fun, f.Name):
+		case strings.Contains(f.Name, "."):
+			qualified = append(qualified, f)
+		default:
+			bare = append(bare, f)
+		}
+	}
+	if len(qualified) > 0 {
+		return qualified
+	}
+	return bare
+}
+
+// matchGoPkgFunc reports whether the called expression is the function f.Name
+// of package f.Pkg: called through the file's name for the import, after a
+// dot import, or from inside the package.
+func matchGoPkgFunc(fun ast.Expr, f GoFunc, imports goImports) bool {
+	switch fn := fun.(type) {
+	case *ast.Ident:
+		return fn.Name == f.Name && (imports.dot[f.Pkg] || imports.pkg == path.Base(f.Pkg))
+	case *ast.SelectorExpr:
+		x, ok := fn.X.(*ast.Ident)
+		return ok && fn.Sel.Name == f.Name && imports.names[x.Name] == f.Pkg
+	case *ast.IndexExpr:
+		return matchGoPkgFunc(fn.X, f, imports)
+	}
+	return false
+}
+
+// matchGoFunc reports whether the called expression is the named function.
+func matchGoFunc(fun ast.Expr, name string) bool {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name == name
+	case *ast.SelectorExpr:
+		if f.Sel.Name == name {
+			return true
+		}
+		if x, ok := f.X.(*ast.Ident); ok {
+			return x.Name+"."+f.Sel.Name == name
+		}
+	case *ast.IndexExpr: // generic instantiation, e.g. T[string](key)
+		return matchGoFunc(f.X, name)
+	}
+	return false
+}
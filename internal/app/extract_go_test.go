@@ -0,0 +1,117 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const goSample = `package main
+
+import "github.com/mlechner911/i18ntool/internal/simpletrans"
+
+func main() {
+	var t simpletrans.Translations
+	simpletrans.GetTranslation(t, "app.title", nil, "")
+	msg := "not.a.key"
+	simpletrans.GetTranslation(t, msg, nil, "unknown")
+	_ = T(` + "`raw.key`" + `)
+	_ = translate("cli.usage")
+	simpletrans.GetPlural(t, "pl", "cart.items", 2, nil)
+	simpletrans.FormatMessage(t, lang, "inbox", nil)
+	var l *simpletrans.Localizer
+	_ = l.Plural("cart.items", 3, nil)
+	_ = time.Now().Format("2006-01-02")
+	_, _, _ = b.GetPlural("de", "bundle.items", 1, nil)
+}
+`
+
+func TestExtractGoKeys_DefaultFuncs(t *testing.T) {
+	refs, err := ExtractGoKeys("main.go", []byte(goSample), DefaultGoFuncs)
+	if err != nil {
+		t.Fatalf("ExtractGoKeys: %v", err)
+	}
+	want := []KeyRef{
+		{Key: "app.title", File: "main.go", Line: 7, Column: 33},
+		{Key: "raw.key", File: "main.go", Line: 10, Column: 9},
+		{Key: "cart.items", File: "main.go", Line: 12, Column: 34},
+		{Key: "inbox", File: "main.go", Line: 13, Column: 38},
+		{Key: "cart.items", File: "main.go", Line: 15, Column: 16},
+		{Key: "bundle.items", File: "main.go", Line: 17, Column: 31},
+	}
+	if !reflect.DeepEqual(refs, want) {
+		t.Fatalf("unexpected refs\nwant: %+v\ngot:  %+v", want, refs)
+	}
+
+	// the package functions are found through the import path, not the name
+	aliased := `package main
+
+import (
+	st "github.com/mlechner911/i18ntool/internal/simpletrans"
+	. "github.com/mlechner911/i18ntool/internal/simpletrans"
+)
+
+func main() {
+	st.GetPlural(t, "fr", "item", 2, nil)
+	FormatMessage(t, "fr", "inbox", nil)
+}
+`
+	refs, err = ExtractGoKeys("alias.go", []byte(aliased), DefaultGoFuncs)
+	if err != nil {
+		t.Fatalf("ExtractGoKeys: %v", err)
+	}
+	want = []KeyRef{
+		{Key: "item", File: "alias.go", Line: 9, Column: 25},
+		{Key: "inbox", File: "alias.go", Line: 10, Column: 26},
+	}
+	if !reflect.DeepEqual(refs, want) {
+		t.Fatalf("unexpected refs\nwant: %+v\ngot:  %+v", want, refs)
+	}
+}
+
+func TestParseGoFunc(t *testing.T) {
+	f, err := ParseGoFunc("translate:1")
+	if err != nil || f != (GoFunc{Name: "translate", Arg: 0}) {
+		t.Fatalf("unexpected result %+v, %v", f, err)
+	}
+	for _, bad := range []string{"", ":1", "T:0", "T:x"} {
+		if _, err := ParseGoFunc(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestFindUnusedKeys_GoSourcesAreParsed(t *testing.T) {
+	dir := t.TempDir()
+	locale := filepath.Join(dir, "en.json")
+	if err := os.WriteFile(locale, []byte(`{"app": {"title": "x"}, "unknown": "y", "cli": {"usage": "z"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(goSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tm, err := NewTranslationManager(map[string]string{"en": locale})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// "unknown" appears in the Go file, but not as a key argument
+	unused, err := tm.FindUnusedKeys([]string{dir}, ScanConfig{})
+	if err != nil {
+		t.Fatalf("FindUnusedKeys: %v", err)
+	}
+	if want := []string{"cli.usage", "unknown"}; !reflect.DeepEqual(unused, want) {
+		t.Fatalf("want %v, got %v", want, unused)
+	}
+
+	goExt, _ := Preset("go")
+	goExt.GoFuncs = append(goExt.GoFuncs, GoFunc{Name: "translate"})
+	unused, err = tm.FindUnusedKeys([]string{dir}, ScanConfig{Extractors: []Extractor{goExt}})
+	if err != nil {
+		t.Fatalf("FindUnusedKeys: %v", err)
+	}
+	if want := []string{"unknown"}; !reflect.DeepEqual(unused, want) {
+		t.Fatalf("want %v, got %v", want, unused)
+	}
+}
@@ -0,0 +1,256 @@
+package app
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Extractor finds key references in the files whose base name matches one
+// of Globs. Go extractors (GoFuncs set) parse the file; all others apply
+// Patterns, whose first capture group is the key.
+type Extractor struct {
+	Name     string
+	Globs    []string
+	Patterns []*regexp.Regexp
+	GoFuncs  []GoFunc
+}
+
+// ScanConfig selects the extractors and the directories skipped while
+// walking project paths. The zero value uses DefaultScanConfig.
+type ScanConfig struct {
+	Extractors []Extractor
+	SkipDirs   []string
+}
+
+// DefaultSkipDirs are never descended into. testdata holds fixtures that the
+// Go tools ignore as well.
+var DefaultSkipDirs = []string{"node_modules", ".git", "vendor", "dist", "testdata"}
+
+var scriptGlobs = []string{"*.js", "*.jsx", "*.mjs", "*.ts", "*.tsx"}
+
+// callPatterns matches string literal first arguments of the functions in
+// the funcs alternation, e.g. t('key'), this.$t("key") or i18n.t(`key`).
+// Literals that are concatenated or interpolated are not keys and are skipped.
+func callPatterns(funcs string) []*regexp.Regexp {
+	prefix := `(?:^|[^\w$])(?:` + funcs + `)\(\s*`
+	return []*regexp.Regexp{
+		regexp.MustCompile(prefix + `'([^'\n]+)'\s*[,)]`),
+		regexp.MustCompile(prefix + `"([^"\n]+)"\s*[,)]`),
+		regexp.MustCompile(prefix + "`([^`$\\n]+)`\\s*[,)]"),
+	}
+}
+
+// presets are the built-in extractors selectable by name.
+var presets = map[string]Extractor{
+	"vue-i18n": {
+		Name:  "vue-i18n",
+		Globs: append([]string{"*.vue", "*.html"}, scriptGlobs...),
+		Patterns: append(callPatterns(`\$?tc?|\$te|\$d|\$n|i18n\.t`),
+			regexp.MustCompile(`v-t="'([^'\n]+)'"`),
+			regexp.MustCompile(`\bkeypath="([^"\n]+)"`),
+		),
+	},
+	"i18next": {
+		Name:  "i18next",
+		Globs: scriptGlobs,
+		Patterns: append(callPatterns(`t|i18n\.t|i18next\.t`),
+			regexp.MustCompile(`\bi18nKey=\{?["']([^"'\n]+)["']`),
+		),
+	},
+	"react-intl": {
+		Name:  "react-intl",
+		Globs: scriptGlobs,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`<Formatted\w+\s[^>]*?\bid=\{?["']([^"'\n]+)["']`),
+			regexp.MustCompile(`formatMessage\(\s*\{[^}]*?\bid:\s*["']([^"'\n]+)["']`),
+		},
+	},
+	"go": {
+		Name:    "go",
+		Globs:   []string{"*.go"},
+		GoFuncs: DefaultGoFuncs,
+	},
+}
+
+// PresetNames returns the names of the built-in extractors.
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preset returns a copy of the named built-in extractor.
+func Preset(name string) (Extractor, error) {
+	e, ok := presets[name]
+	if !ok {
+		return Extractor{}, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(PresetNames(), ", "))
+	}
+	e.Globs = append([]string(nil), e.Globs...)
+	e.Patterns = append([]*regexp.Regexp(nil), e.Patterns...)
+	e.GoFuncs = append([]GoFunc(nil), e.GoFuncs...)
+	return e, nil
+}
+
+// DefaultScanConfig covers vue-i18n and i18next style calls in scripts and
+// components, t()/_()/gettext() calls in Svelte, HTML and Python files,
+// {{ t "key" }} in Go templates, and Go sources.
+func DefaultScanConfig() ScanConfig {
+	vue, _ := Preset("vue-i18n")
+	next, _ := Preset("i18next")
+	goExt, _ := Preset("go")
+	generic := Extractor{
+		Name:     "generic",
+		Globs:    []string{"*.svelte", "*.html", "*.py"},
+		Patterns: callPatterns(`\$?t|_|gettext`),
+	}
+	templates := Extractor{
+		Name:     "templates",
+		Globs:    []string{"*.tmpl", "*.gohtml", "*.tpl"},
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`\{\{-?\s*(?:T|t|tn|tm|translate)\s+"([^"\n]+)"`)},
+	}
+	return ScanConfig{
+		Extractors: []Extractor{vue, next, generic, templates, goExt},
+		SkipDirs:   DefaultSkipDirs,
+	}
+}
+
+// ParsePattern parses a "glob=regex" specification into a custom extractor.
+// The regex must have a capture group for the key.
+func ParsePattern(spec string) (Extractor, error) {
+	glob, expr, ok := strings.Cut(spec, "=")
+	if !ok || glob == "" || expr == "" {
+		return Extractor{}, fmt.Errorf("invalid pattern %q: expected glob=regex", spec)
+	}
+	if _, err := filepath.Match(glob, ""); err != nil {
+		return Extractor{}, fmt.Errorf("invalid pattern %q: %w", spec, err)
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return Extractor{}, fmt.Errorf("invalid pattern %q: %w", spec, err)
+	}
+	if re.NumSubexp() < 1 {
+		return Extractor{}, fmt.Errorf("invalid pattern %q: no capture group for the key", spec)
+	}
+	return Extractor{Name: "custom", Globs: []string{glob}, Patterns: []*regexp.Regexp{re}}, nil
+}
+
+// matches reports whether the extractor handles the file.
+func (e Extractor) matches(path string) bool {
+	base := filepath.Base(path)
+	for _, glob := range e.Globs {
+		if ok, _ := filepath.Match(glob, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// extract returns the key references in content.
+func (e Extractor) extract(path string, content []byte) ([]KeyRef, error) {
+	if len(e.GoFuncs) > 0 {
+		return ExtractGoKeys(path, content, e.GoFuncs)
+	}
+	var refs []KeyRef
+	for _, re := range e.Patterns {
+		for _, loc := range re.FindAllSubmatchIndex(content, -1) {
+			if loc[2] < 0 {
+				continue
+			}
+			line, col := lineColumn(content, loc[2])
+			refs = append(refs, KeyRef{Key: string(content[loc[2]:loc[3]]), File: path, Line: line, Column: col})
+		}
+	}
+	return refs, nil
+}
+
+// lineColumn converts a byte offset into a 1-based line and column.
+func lineColumn(content []byte, offset int) (int, int) {
+	line, start := 1, 0
+	for i := 0; i < offset && i < len(content); i++ {
+		if content[i] == '\n' {
+			line++
+			start = i + 1
+		}
+	}
+	return line, offset - start + 1
+}
+
+// ScanSources walks the project paths and returns every key reference found
+// by the configured extractors, sorted by file and position. Files an
+// extractor cannot parse are skipped with a warning on stderr.
+func ScanSources(projectPaths []string, cfg ScanConfig) ([]KeyRef, error) {
+	if len(cfg.Extractors) == 0 {
+		cfg.Extractors = DefaultScanConfig().Extractors
+	}
+	if cfg.SkipDirs == nil {
+		cfg.SkipDirs = DefaultSkipDirs
+	}
+	skip := make(map[string]bool, len(cfg.SkipDirs))
+	for _, dir := range cfg.SkipDirs {
+		skip[dir] = true
+	}
+
+	var refs []KeyRef
+	seen := make(map[KeyRef]bool)
+	for _, projectPath := range projectPaths {
+		err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != projectPath && skip[d.Name()] {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			var content []byte
+			for _, e := range cfg.Extractors {
+				if !e.matches(path) {
+					continue
+				}
+				if content == nil {
+					if content, err = os.ReadFile(path); err != nil {
+						return err
+					}
+				}
+				found, err := e.extract(path, content)
+				if err != nil {
+					// one broken file (a fixture, a half-written edit) should not hide
+					// the references in all the others
+					fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", path, err)
+					continue
+				}
+				for _, ref := range found {
+					if !seen[ref] {
+						seen[ref] = true
+						refs = append(refs, ref)
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", projectPath, err)
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].File != refs[j].File {
+			return refs[i].File < refs[j].File
+		}
+		if refs[i].Line != refs[j].Line {
+			return refs[i].Line < refs[j].Line
+		}
+		return refs[i].Column < refs[j].Column
+	})
+	return refs, nil
+}
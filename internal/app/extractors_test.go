@@ -0,0 +1,133 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func refKeys(refs []KeyRef) []string {
+	keys := make([]string, 0, len(refs))
+	for _, r := range refs {
+		keys = append(keys, r.Key)
+	}
+	return keys
+}
+
+func TestPresets_MatchCallSitesOnly(t *testing.T) {
+	cases := []struct {
+		preset string
+		file   string
+		src    string
+		want   []string
+	}{
+		{"vue-i18n", "App.vue", "<p>{{ $t('home.title') }}</p>\n<i18n-t keypath=\"home.body\" />\n<p v-t=\"'home.footer'\"></p>\n<script>const unknown = format('x'); this.$t(`dyn.${id}`)</script>", []string{"home.title", "home.body", "home.footer"}},
+		{"i18next", "App.tsx", "const a = t(\"nav.home\");\nconst b = i18n.t('nav.about', { count: 2 });\n<Trans i18nKey=\"nav.contact\" />\nt('prefix.' + name)", []string{"nav.home", "nav.about", "nav.contact"}},
+		{"react-intl", "Page.jsx", "<FormattedMessage id=\"page.title\" defaultMessage=\"Title\" />\nintl.formatMessage({ id: 'page.body' })", []string{"page.title", "page.body"}},
+	}
+	for _, c := range cases {
+		e, err := Preset(c.preset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !e.matches(c.file) {
+			t.Fatalf("%s: expected %s to match", c.preset, c.file)
+		}
+		refs, err := e.extract(c.file, []byte(c.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := refKeys(refs)
+		for _, want := range c.want {
+			found := false
+			for _, k := range got {
+				found = found || k == want
+			}
+			if !found {
+				t.Errorf("%s: expected key %q in %v", c.preset, want, got)
+			}
+		}
+		if len(got) != len(c.want) {
+			t.Errorf("%s: expected %d keys, got %v", c.preset, len(c.want), got)
+		}
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	e, err := ParsePattern(`*.py=_\("([^"]+)"\)`)
+	if err != nil {
+		t.Fatalf("ParsePattern: %v", err)
+	}
+	refs, _ := e.extract("app.py", []byte("x = 1\nprint(_(\"greeting\"))\n"))
+	if want := []KeyRef{{Key: "greeting", File: "app.py", Line: 2, Column: 10}}; !reflect.DeepEqual(refs, want) {
+		t.Fatalf("want %+v, got %+v", want, refs)
+	}
+	for _, bad := range []string{"*.py", "*.py=_\\(", "*.py=no-group", "[=x(y)"} {
+		if _, err := ParsePattern(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+	if _, err := Preset("angular"); err == nil {
+		t.Errorf("expected error for unknown preset")
+	}
+}
+
+func TestScanSources_SkipDirsAndFileTypes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"src/Page.svelte":       "<h1>{$t('svelte.title')}</h1>",
+		"src/views/index.html":  "<p>{{ t(\"html.body\") }}</p>",
+		"src/app.py":            "print(_('py.hello'))",
+		"templates/base.tmpl":   "{{ T \"tmpl.title\" }}",
+		"node_modules/lib/x.js": "t('vendored.key')",
+		"build/out.js":          "t('build.key')",
+		"src/ignored.txt":       "t('txt.key')",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := DefaultScanConfig()
+	cfg.SkipDirs = append(cfg.SkipDirs, "build")
+	refs, err := ScanSources([]string{dir}, cfg)
+	if err != nil {
+		t.Fatalf("ScanSources: %v", err)
+	}
+	want := []string{"svelte.title", "py.hello", "html.body", "tmpl.title"}
+	if got := refKeys(refs); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestScanSources_SkipsUnparsableGoFilesAndTestdata(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"src/ok.go":               "package src\n\nvar _ = T(\"go.key\")\n",
+		"src/broken.go":           "package src\n\nfunc {",
+		"src/testdata/fixture.go": "package fixture\n\nvar _ = T(\"fixture.key\")\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	refs, err := ScanSources([]string{dir}, DefaultScanConfig())
+	if err != nil {
+		t.Fatalf("ScanSources: %v", err)
+	}
+	if got, want := refKeys(refs), []string{"go.key"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
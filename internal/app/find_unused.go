@@ -1,58 +1,46 @@
 package app
 
-import (
-	"fmt"
-	"io/fs"
-	"os"
-	"path/filepath"
-	"strings"
-)
+import "strings"
 
-// FindUnusedKeys scans project paths and returns keys that are not referenced.
-func (tm *TranslationManager) FindUnusedKeys(projectPaths []string) ([]string, error) {
+// FindUnusedKeys scans project paths with the configured extractors and
+// returns keys that are not referenced. As in FindUndefinedKeys, a reference
+// to the base of i18next plural keys uses every form (item_one, item_other),
+// and a reference to an object uses every key below it.
+func (tm *TranslationManager) FindUnusedKeys(projectPaths []string, cfg ScanConfig) ([]string, error) {
 	allKeys := tm.GetAllKeys()
-	usedKeys := make(map[string]bool)
 
-	for _, projectPath := range projectPaths {
-		err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return err
-			}
-
-			if d.IsDir() && (d.Name() == "node_modules" || d.Name() == ".git") {
-				return filepath.SkipDir
-			}
-
-			if !d.IsDir() && (strings.HasSuffix(path, ".vue") ||
-				strings.HasSuffix(path, ".ts") ||
-				strings.HasSuffix(path, ".js")) {
-
-				content, err := os.ReadFile(path)
-				if err != nil {
-					return err
-				}
-
-				contentStr := string(content)
-				for _, key := range allKeys {
-					if strings.Contains(contentStr, key) {
-						usedKeys[key] = true
-					}
-				}
-			}
-			return nil
-		})
-
-		if err != nil {
-			return nil, fmt.Errorf("scanning %s: %w", projectPath, err)
-		}
+	refs, err := ScanSources(projectPaths, cfg)
+	if err != nil {
+		return nil, err
+	}
+	usedKeys := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		usedKeys[ref.Key] = true
 	}
 
 	unused := make([]string, 0)
 	for _, key := range allKeys {
-		if !usedKeys[key] {
+		if !keyUsed(key, usedKeys) {
 			unused = append(unused, key)
 		}
 	}
 
 	return unused, nil
 }
+
+// keyUsed reports whether key, its plural base or one of its parent objects
+// is in used.
+func keyUsed(key string, used map[string]bool) bool {
+	if used[key] {
+		return true
+	}
+	if base, _, ok := splitPluralKey(key); ok && used[base] {
+		return true
+	}
+	for i := strings.LastIndex(key, "."); i > 0; i = strings.LastIndex(key[:i], ".") {
+		if used[key[:i]] {
+			return true
+		}
+	}
+	return false
+}
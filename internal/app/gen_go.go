@@ -0,0 +1,154 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// GoOptions configures GenerateGo.
+type GoOptions struct {
+	Package string // package of the generated file; defaults to the output directory's name
+	Out     string // path of the generated file
+	Source  string // language values and parameters come from; defaults to ReferenceLanguage
+}
+
+// GenerateGo returns a Go file with a Key constant for every key and one
+// accessor function per key, so the compiler catches missing or renamed
+// keys. The parameters of an accessor come from the source value. Template
+// fields ({{.Name}}) become interface{} parameters. Printf verbs become
+// parameters typed by verb, e.g. %d becomes int. MessageFormat arguments
+// become parameters too, and plural keys take the count first. Accessors
+// render through a Localizer interface declared in the file, which
+// *simpletrans.Localizer implements.
+func (tm *TranslationManager) GenerateGo(opts GoOptions) ([]byte, error) {
+	pkg, err := packageName(opts.Package, opts.Out)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Source == "" {
+		opts.Source = tm.ReferenceLanguage()
+	}
+	entries, err := tm.codegenEntries(opts.Source)
+	if err != nil {
+		return nil, err
+	}
+	names := uniqueNames(entries, "Key", "Localizer")
+	usesFmt := false
+	for _, e := range entries {
+		if e.Kind == entryPrintf {
+			usesFmt = true
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by i18n-manager gen-go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	if usesFmt {
+		buf.WriteString("import \"fmt\"\n\n")
+	}
+	buf.WriteString(`// Localizer renders translations; *simpletrans.Localizer implements it.
+type Localizer interface {
+	T(key string, data map[string]interface{}) string
+	Plural(key string, n interface{}, data map[string]interface{}) string
+	Format(key string, args map[string]interface{}) string
+}
+
+`)
+	fmt.Fprintf(&buf, "// Translation keys of the %s locale.\nconst (\n", opts.Source)
+	for i, e := range entries {
+		fmt.Fprintf(&buf, "Key%s = %s\n", names[i], strconv.Quote(e.Key))
+	}
+	buf.WriteString(")\n")
+	for i, e := range entries {
+		if !e.Form {
+			writeGoAccessor(&buf, names[i], e)
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated source: %w", err)
+	}
+	return src, nil
+}
+
+// writeGoAccessor writes the accessor function of one entry.
+func writeGoAccessor(buf *bytes.Buffer, name string, e codegenEntry) {
+	used := map[string]bool{"l": true}
+	param := func(base string) string {
+		if token.IsKeyword(base) || used[base] {
+			base += "Arg"
+		}
+		p := base
+		for n := 2; used[p]; n++ {
+			p = fmt.Sprintf("%s%d", base, n)
+		}
+		used[p] = true
+		return p
+	}
+
+	sig := []string{"l Localizer"}
+	count := ""
+	if e.Kind == entryPlural {
+		count = param("n")
+		sig = append(sig, count+" interface{}")
+	}
+	var fields, args []string
+	for _, p := range e.Params {
+		if p.Arg > 0 {
+			a := param("arg" + strconv.Itoa(p.Arg))
+			sig = append(sig, a+" "+goVerbType(p.Verb))
+			args = append(args, a)
+			continue
+		}
+		typ := "interface{}"
+		if p.Type == "select" {
+			typ = "string"
+		}
+		a := param(lowerName(p.Field))
+		sig = append(sig, a+" "+typ)
+		fields = append(fields, fmt.Sprintf("%s: %s", strconv.Quote(p.Field), a))
+	}
+	data := "nil"
+	if len(fields) > 0 {
+		data = "map[string]interface{}{" + strings.Join(fields, ", ") + "}"
+	}
+
+	buf.WriteString("\n")
+	if e.Kind == entryPlural {
+		fmt.Fprintf(buf, "// %s renders the plural forms of %s, e.g. %s.\n", name, strconv.Quote(e.Key), strconv.Quote(e.Value))
+	} else {
+		fmt.Fprintf(buf, "// %s renders %s: %s.\n", name, strconv.Quote(e.Key), strconv.Quote(e.Value))
+	}
+	fmt.Fprintf(buf, "func %s(%s) string {\n", name, strings.Join(sig, ", "))
+	switch e.Kind {
+	case entryPlural:
+		fmt.Fprintf(buf, "return l.Plural(Key%s, %s, %s)\n", name, count, data)
+	case entryMessage:
+		fmt.Fprintf(buf, "return l.Format(Key%s, %s)\n", name, data)
+	case entryPrintf:
+		fmt.Fprintf(buf, "return fmt.Sprintf(l.T(Key%s, %s), %s)\n", name, data, strings.Join(args, ", "))
+	default:
+		fmt.Fprintf(buf, "return l.T(Key%s, %s)\n", name, data)
+	}
+	buf.WriteString("}\n")
+}
+
+// goVerbType returns the Go type a printf verb formats.
+func goVerbType(verb string) string {
+	switch verb {
+	case "d", "b", "o", "O", "c", "U":
+		return "int"
+	case "s", "q":
+		return "string"
+	case "e", "E", "f", "F", "g", "G":
+		return "float64"
+	case "t":
+		return "bool"
+	}
+	return "interface{}"
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateGo(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"en": writeLocale(t, dir, "en.json", `{
+			"errors": {"network": {"timeout": "Timed out after {{.Seconds}}s"}},
+			"cart": {"items_one": "{{.Count}} item for {{.User}}", "items_other": "{{.Count}} items for {{.User}}"},
+			"inbox": "{n, plural, one {# message} other {# messages}} from {sender}",
+			"done": "%s done in %.1f seconds (%[1]s)",
+			"type": "{{.type}}",
+			"greeting": "Hello",
+			"key": {"greeting": "Hi"}
+		}`),
+		"de": writeLocale(t, dir, "de.json", `{"extra": "Nur deutsch"}`),
+	}
+	tm, err := NewTranslationManager(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := tm.GenerateGo(GoOptions{Package: "keys"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "keys_gen.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check("keys", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatalf("generated source does not type-check: %v\n%s", err, src)
+	}
+
+	signatures := map[string]string{
+		"ErrorsNetworkTimeout": "func(l keys.Localizer, seconds interface{}) string",
+		"CartItems":            "func(l keys.Localizer, n interface{}, user interface{}) string",
+		"Inbox":                "func(l keys.Localizer, n interface{}, sender interface{}) string",
+		"Done":                 "func(l keys.Localizer, arg1 string, arg2 float64) string",
+		"Type":                 "func(l keys.Localizer, typeArg interface{}) string",
+		"Greeting":             "func(l keys.Localizer) string",
+		"KeyGreeting2":         "func(l keys.Localizer) string",
+		"Extra":                "func(l keys.Localizer) string",
+	}
+	for name, want := range signatures {
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			t.Errorf("missing function %s", name)
+			continue
+		}
+		if got := obj.Type().String(); got != want {
+			t.Errorf("%s: got %s, want %s", name, got, want)
+		}
+	}
+	for name, want := range map[string]string{"KeyCartItems": `"cart.items"`, "KeyCartItemsOne": `"cart.items_one"`, "KeyKeyGreeting2": `"key.greeting"`} {
+		c, ok := pkg.Scope().Lookup(name).(*types.Const)
+		if !ok || c.Val().String() != want {
+			t.Errorf("constant %s: got %v, want %s", name, c, want)
+		}
+	}
+	if pkg.Scope().Lookup("CartItemsOne") != nil {
+		t.Error("plural forms should not get accessors")
+	}
+	if !strings.Contains(string(src), `return l.Plural(KeyCartItems, n, map[string]interface{}{"User": user})`) {
+		t.Errorf("unexpected plural accessor:\n%s", src)
+	}
+}
+
+func TestGenerateGoPackageFromOut(t *testing.T) {
+	dir := t.TempDir()
+	tm, err := NewTranslationManager(map[string]string{"en": writeLocale(t, dir, "en.json", `{"a": "A"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := tm.GenerateGo(GoOptions{Out: filepath.Join("internal", "i18nkeys", "keys_gen.go")})
+	if err != nil || !strings.Contains(string(src), "package i18nkeys\n") {
+		t.Fatalf("unexpected package (%v):\n%s", err, src)
+	}
+	if _, err := tm.GenerateGo(GoOptions{Package: "p", Source: "fr"}); err == nil {
+		t.Error("expected error for a source language that is not loaded")
+	}
+}
@@ -0,0 +1,266 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mlechner911/i18ntool/internal/messageformat"
+)
+
+// Module augmentations GenerateTS can emit.
+const (
+	AugmentI18next = "i18next"
+	AugmentVueI18n = "vue-i18n"
+)
+
+// TSOptions configures GenerateTS.
+type TSOptions struct {
+	Source  string // language values and parameters come from; defaults to ReferenceLanguage
+	Augment string // "", AugmentI18next or AugmentVueI18n
+}
+
+var (
+	tsIdentRe = regexp.MustCompile(`^[A-Za-z_$][\w$]*$`)
+	// i18next interpolation such as {{name}}, {{- html}} or {{value, number}}
+	i18nextVarRe = regexp.MustCompile(`^-?\s*([A-Za-z_$][\w$]*)((?:\.[\w$]+)*)\s*(?:,.*)?$`)
+)
+
+// tsTypes maps MessageFormat argument types to TypeScript types.
+var tsTypes = map[string]string{
+	messageformat.TypeNone:          "string | number",
+	messageformat.TypeNumber:        "number",
+	messageformat.TypePlural:        "number",
+	messageformat.TypeSelectOrdinal: "number",
+	messageformat.TypeSelect:        "string",
+	messageformat.TypeDate:          "Date | number",
+	messageformat.TypeTime:          "Date | number",
+}
+
+// GenerateTS returns a TypeScript declaration file for a frontend:
+//   - a Messages interface with the nested messages of the source language
+//   - a TranslationKey union of every key t() accepts, with plural keys
+//     listed by base
+//   - a TranslationParams interface with the interpolation parameters of
+//     each key
+//   - a TypedT signature
+//
+// Parameters come from i18next {{name}}, vue-i18n {name} and text/template
+// {{.Name}} placeholders, and from ICU MessageFormat arguments, which carry
+// their types. With Augment, the file also registers Messages with i18next
+// or vue-i18n, so t() rejects undefined keys at compile time.
+func (tm *TranslationManager) GenerateTS(opts TSOptions) ([]byte, error) {
+	switch opts.Augment {
+	case "", AugmentI18next, AugmentVueI18n:
+	default:
+		return nil, fmt.Errorf("unknown augmentation %q (expected %s or %s)", opts.Augment, AugmentI18next, AugmentVueI18n)
+	}
+	if opts.Source == "" {
+		opts.Source = tm.ReferenceLanguage()
+	}
+	entries, err := tm.codegenEntries(opts.Source)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(entries))
+	for _, e := range entries {
+		if e.Kind != entryPlural {
+			values[e.Key] = e.Value
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by i18n-manager gen-ts; DO NOT EDIT.\n\n")
+	if opts.Augment != "" {
+		fmt.Fprintf(&buf, "import %s;\n\n", tsString(opts.Augment))
+	}
+
+	fmt.Fprintf(&buf, "/** Translation messages of the %s locale. */\nexport interface Messages ", opts.Source)
+	writeTSMessages(&buf, values, "")
+	buf.WriteString("\n\n")
+
+	buf.WriteString("/** Every key t() accepts; plural keys appear without their _one/_other suffix. */\nexport type TranslationKey =")
+	keys := 0
+	for _, e := range entries {
+		if !e.Form {
+			fmt.Fprintf(&buf, "\n  | %s", tsString(e.Key))
+			keys++
+		}
+	}
+	if keys == 0 {
+		buf.WriteString(" never")
+	}
+	buf.WriteString(";\n\n")
+
+	buf.WriteString("/** The interpolation parameters of each key. */\nexport interface TranslationParams {\n")
+	for _, e := range entries {
+		if e.Form {
+			continue
+		}
+		params := tsParams(e, values)
+		if len(params) == 0 {
+			// keyof Record<never, never> is never, which TypedT tests for
+			fmt.Fprintf(&buf, "  %s: Record<never, never>;\n", tsString(e.Key))
+			continue
+		}
+		fields := make([]string, len(params))
+		for i, p := range params {
+			fields[i] = tsProperty(p[0]) + ": " + p[1]
+		}
+		fmt.Fprintf(&buf, "  %s: { %s };\n", tsString(e.Key), strings.Join(fields, "; "))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(`/** A t() that checks keys and requires the parameters a key needs. */
+export type TypedT = <K extends TranslationKey>(
+  key: K,
+  ...params: [keyof TranslationParams[K]] extends [never] ? [params?: TranslationParams[K]] : [params: TranslationParams[K]]
+) => string;
+`)
+
+	switch opts.Augment {
+	case AugmentI18next:
+		buf.WriteString(`
+declare module "i18next" {
+  interface CustomTypeOptions {
+    defaultNS: "translation";
+    resources: { translation: Messages };
+  }
+}
+`)
+	case AugmentVueI18n:
+		buf.WriteString(`
+declare module "vue-i18n" {
+  export interface DefineLocaleMessage extends Messages {}
+}
+`)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeTSMessages writes the object type of the keys below prefix, with the
+// values as string literal types so i18next can infer interpolations.
+func writeTSMessages(buf *bytes.Buffer, values map[string]string, prefix string) {
+	children := make(map[string]bool)
+	for key := range values {
+		if rest, ok := strings.CutPrefix(key, prefix); ok {
+			name, _, _ := strings.Cut(rest, ".")
+			children[name] = true
+		}
+	}
+	names := make([]string, 0, len(children))
+	for name := range children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	indent := strings.Repeat("  ", strings.Count(prefix, ".")+1)
+	buf.WriteString("{\n")
+	for _, name := range names {
+		key := prefix + name
+		if v, ok := values[key]; ok {
+			fmt.Fprintf(buf, "%s%s: %s;\n", indent, tsProperty(name), tsString(v))
+			continue
+		}
+		fmt.Fprintf(buf, "%s%s: ", indent, tsProperty(name))
+		writeTSMessages(buf, values, key+".")
+		buf.WriteString(";\n")
+	}
+	buf.WriteString(indent[2:] + "}")
+}
+
+// tsParams returns the name and TypeScript type of each parameter of e.
+func tsParams(e codegenEntry, values map[string]string) [][2]string {
+	var params [][2]string
+	add := func(name, typ string) {
+		for _, p := range params {
+			if p[0] == name {
+				return
+			}
+		}
+		params = append(params, [2]string{name, typ})
+	}
+	switch e.Kind {
+	case entryMessage:
+		for _, p := range e.Params {
+			typ, ok := tsTypes[p.Type]
+			if !ok {
+				typ = "number" // spellout, ordinal, duration
+			}
+			add(p.Field, typ)
+		}
+		return params
+	case entryPlural:
+		add("count", "number")
+		for _, form := range e.Forms {
+			for _, p := range interpolationParams(values[form]) {
+				if p[0] != "Count" {
+					add(p[0], p[1])
+				}
+			}
+		}
+		return params
+	}
+	for _, p := range interpolationParams(e.Value) {
+		add(p[0], p[1])
+	}
+	return params
+}
+
+// interpolationParams returns the root names of the {{name}}, {{.Name}} and
+// {name} placeholders of s with their TypeScript types, in order of first
+// appearance. A root used with a path, as in {{user.name}}, is an object.
+func interpolationParams(s string) [][2]string {
+	var params [][2]string
+	add := func(root string, nested bool) {
+		typ := "string | number"
+		if nested {
+			typ = "Record<string, unknown>"
+		}
+		for i, p := range params {
+			if p[0] == root {
+				if nested {
+					params[i][1] = typ
+				}
+				return
+			}
+		}
+		params = append(params, [2]string{root, typ})
+	}
+	for _, p := range extractPlaceholders(s) {
+		switch p.Kind {
+		case PlaceholderTemplate:
+			if m := i18nextVarRe.FindStringSubmatch(p.Name); m != nil {
+				add(m[1], m[2] != "")
+				continue
+			}
+			for _, f := range templateFieldRe.FindAllStringSubmatch(p.Name, -1) {
+				add(f[1], f[2] != "")
+			}
+		case PlaceholderBrace:
+			root, rest, _ := strings.Cut(p.Name, ".")
+			add(root, rest != "")
+		}
+	}
+	return params
+}
+
+// tsString quotes s as a TypeScript string literal.
+func tsString(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// tsProperty returns name as a property name, quoted unless an identifier.
+func tsProperty(name string) string {
+	if tsIdentRe.MatchString(name) {
+		return name
+	}
+	return tsString(name)
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateTS(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"en": writeLocale(t, dir, "en.json", `{
+			"greeting": "Hello {{name}}, {{- html}} {{value, number}} {{user.email}}",
+			"cart": {"items_one": "{{count}} item for {{user}}", "items_other": "{{count}} items for {{user}}"},
+			"inbox": "{n, plural, one {# message} other {# messages}} from {sender} on {d, date}",
+			"vue": "Hello {name}!",
+			"go": "Hi {{.Name}}",
+			"odd-key": "plain \"quoted\""
+		}`),
+		"de": writeLocale(t, dir, "de.json", `{"extra": "Nur deutsch"}`),
+	}
+	tm, err := NewTranslationManager(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := tm.GenerateTS(TSOptions{Augment: AugmentI18next})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(src)
+	for _, want := range []string{
+		"import \"i18next\";\n",
+		"export interface Messages {\n  cart: {\n    items_one: \"{{count}} item for {{user}}\";\n",
+		"  extra: \"Nur deutsch\";\n",
+		"  \"odd-key\": \"plain \\\"quoted\\\"\";\n",
+		"export type TranslationKey =\n  | \"cart.items\"\n  | \"extra\"\n",
+		`"greeting": { name: string | number; html: string | number; value: string | number; user: Record<string, unknown> };`,
+		`"cart.items": { count: number; user: string | number };`,
+		`"inbox": { d: Date | number; n: number; sender: string | number };`,
+		`"vue": { name: string | number };`,
+		`"go": { Name: string | number };`,
+		`"odd-key": Record<never, never>;`,
+		"...params: [keyof TranslationParams[K]] extends [never] ? [params?: TranslationParams[K]] : [params: TranslationParams[K]]",
+		"resources: { translation: Messages };",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `| "cart.items_one"`) {
+		t.Error("plural forms should not be keys")
+	}
+
+	vue, err := tm.GenerateTS(TSOptions{Augment: AugmentVueI18n})
+	if err != nil || !strings.Contains(string(vue), "export interface DefineLocaleMessage extends Messages {}") {
+		t.Errorf("unexpected vue-i18n output (%v):\n%s", err, vue)
+	}
+	if _, err := tm.GenerateTS(TSOptions{Augment: "react-intl"}); err == nil {
+		t.Error("expected error for unknown augmentation")
+	}
+}
@@ -1,10 +1,12 @@
 package app
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"sort"
+	"time"
 )
 
 // NewTranslationManager loads the provided files and returns a TranslationManager.
@@ -12,6 +14,7 @@ func NewTranslationManager(files map[string]string) (*TranslationManager, error)
 	tm := &TranslationManager{
 		files:     files,
 		data:      make(map[string]map[string]interface{}),
+		docs:      make(map[string]*jsonDocument),
 		Languages: make([]string, 0, len(files)),
 	}
 
@@ -22,13 +25,59 @@ func NewTranslationManager(files map[string]string) (*TranslationManager, error)
 			return nil, fmt.Errorf("reading %s: %w", path, err)
 		}
 
-		var data map[string]interface{}
-		if err := json.Unmarshal(content, &data); err != nil {
+		doc, err := parseDocument(content)
+		if err != nil {
 			return nil, fmt.Errorf("parsing %s: %w", path, err)
 		}
-		tm.data[lang] = data
+		tm.docs[lang] = doc
+		tm.data[lang] = doc.toMap()
 	}
 
 	sort.Strings(tm.Languages)
 	return tm, nil
 }
+
+// writeBackup writes content next to path as <path>.backup.<timestamp>.
+func writeBackup(path string, content []byte) error {
+	backupPath := path + ".backup." + time.Now().Format("20060102-150405")
+	if err := os.WriteFile(backupPath, content, 0644); err != nil {
+		return fmt.Errorf("creating backup %s: %w", backupPath, err)
+	}
+	fmt.Printf("Backup created: %s\n", backupPath)
+	return nil
+}
+
+// backupIfExists backs up path when it already exists on disk.
+func backupIfExists(path string) error {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s for backup: %w", path, err)
+	}
+	return writeBackup(path, content)
+}
+
+// writeDocument encodes doc to path.
+func writeDocument(path string, doc *jsonDocument) error {
+	if err := os.WriteFile(path, doc.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// saveLanguage writes the document of lang back to its file, optionally backing up the original first.
+func (tm *TranslationManager) saveLanguage(lang string, backup bool) error {
+	path := tm.files[lang]
+	if backup {
+		if err := backupIfExists(path); err != nil {
+			return err
+		}
+	}
+	if err := writeDocument(path, tm.docs[lang]); err != nil {
+		return err
+	}
+	tm.data[lang] = tm.docs[lang].toMap()
+	return nil
+}
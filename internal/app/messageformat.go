@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/mlechner911/i18ntool/internal/messageformat"
+)
+
+// MessageFormatIssue is an ICU MessageFormat value that does not parse, or
+// whose arguments differ from the reference language. Kind is
+// RuleICUSyntax or RuleICUArguments.
+type MessageFormatIssue struct {
+	Kind      string   `json:"kind"`
+	Key       string   `json:"key"`
+	Language  string   `json:"language"`
+	File      string   `json:"file"`
+	Reference string   `json:"reference,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	Expected  []string `json:"expected,omitempty"`
+	Found     []string `json:"found,omitempty"`
+}
+
+// icuKeys returns the keys whose value uses typed MessageFormat arguments
+// ({n, plural, ...}) in at least one language. Their values are checked as
+// MessageFormat in every language instead of by CheckPlaceholders.
+func (tm *TranslationManager) icuKeys() map[string]bool {
+	keys := make(map[string]bool)
+	for _, lang := range tm.Languages {
+		for key, val := range tm.flattenKeys("", tm.data[lang]) {
+			if s, ok := val.(string); ok && messageformat.LooksLikeICU(s) {
+				keys[key] = true
+			}
+		}
+	}
+	return keys
+}
+
+// CheckMessageFormat parses every MessageFormat value and compares its
+// arguments, with their types, with the reference language. Arguments may
+// be reordered, but a plural in one language must be a plural in all.
+func (tm *TranslationManager) CheckMessageFormat(reference string) []MessageFormatIssue {
+	icu := tm.icuKeys()
+	keys := make([]string, 0, len(icu))
+	for key := range icu {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parsed := make(map[string]map[string]*messageformat.Message, len(tm.Languages))
+	issues := make([]MessageFormatIssue, 0)
+	for _, lang := range tm.Languages {
+		flat := tm.flattenKeys("", tm.data[lang])
+		parsed[lang] = make(map[string]*messageformat.Message)
+		for _, key := range keys {
+			s, ok := flat[key].(string)
+			if !ok {
+				continue
+			}
+			m, err := messageformat.Parse(s)
+			if err != nil {
+				issues = append(issues, MessageFormatIssue{Kind: RuleICUSyntax, Key: key, Language: lang, File: tm.files[lang], Error: err.Error()})
+				continue
+			}
+			parsed[lang][key] = m
+		}
+	}
+
+	for _, lang := range tm.Languages {
+		if lang == reference {
+			continue
+		}
+		for _, key := range keys {
+			ref, ok := parsed[reference][key]
+			m, ok2 := parsed[lang][key]
+			if !ok || !ok2 {
+				continue
+			}
+			want, got := ref.ArgumentList(), m.ArgumentList()
+			if !reflect.DeepEqual(want, got) {
+				issues = append(issues, MessageFormatIssue{Kind: RuleICUArguments, Key: key, Language: lang, File: tm.files[lang],
+					Reference: reference, Expected: want, Found: got})
+			}
+		}
+	}
+	return issues
+}
+
+// MessageFormatIssues converts CheckMessageFormat results into report issues.
+func (tm *TranslationManager) MessageFormatIssues(mf []MessageFormatIssue) []Issue {
+	issues := make([]Issue, 0, len(mf))
+	for _, m := range mf {
+		msg := fmt.Sprintf("key %q is not valid ICU MessageFormat: %s", m.Key, m.Error)
+		if m.Kind == RuleICUArguments {
+			msg = fmt.Sprintf("key %q uses arguments %v, %s uses %v", m.Key, m.Found, m.Reference, m.Expected)
+		}
+		issues = append(issues, Issue{
+			Rule:     m.Kind,
+			Level:    "error",
+			Key:      m.Key,
+			Language: m.Language,
+			File:     m.File,
+			Message:  msg,
+		})
+	}
+	return issues
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckMessageFormat(t *testing.T) {
+	dir := t.TempDir()
+	en := writeLocale(t, dir, "en.json", `{"files": "{n, plural, one {# file} other {# files}} in {dir}", "who": "{g, select, female {She} other {They}}", "plain": "Hello {name}"}`)
+	de := writeLocale(t, dir, "de.json", `{"files": "{n, plural, one {# Datei} other {# Dateien}} in {folder}", "who": "{g, select, female {Sie}}", "plain": "Hallo {name}"}`)
+	tm, err := NewTranslationManager(map[string]string{"en": en, "de": de})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues := tm.CheckMessageFormat("en")
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %+v", issues)
+	}
+	want := MessageFormatIssue{Kind: RuleICUArguments, Key: "files", Language: "de", File: de, Reference: "en",
+		Expected: []string{"dir", "n:plural"}, Found: []string{"folder", "n:plural"}}
+	if !reflect.DeepEqual(issues[1], want) {
+		t.Fatalf("want %+v, got %+v", want, issues[1])
+	}
+	if issues[0].Kind != RuleICUSyntax || issues[0].Key != "who" || issues[0].Language != "de" {
+		t.Fatalf("unexpected syntax issue %+v", issues[0])
+	}
+
+	// MessageFormat keys are not compared as {name} placeholders as well
+	for _, m := range tm.CheckPlaceholders("en") {
+		if m.Key == "files" {
+			t.Fatalf("files reported by CheckPlaceholders: %+v", m)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Placeholder kinds recognised by extractPlaceholders.
+const (
+	PlaceholderPrintf   = "printf"   // fmt verbs such as %d or %[2]s
+	PlaceholderTemplate = "template" // {{.Name}} (text/template) or {{name}} (i18next)
+	PlaceholderBrace    = "brace"    // {name} tokens
+)
+
+// Reasons reported by CheckPlaceholders.
+const (
+	MismatchSet   = "set"   // placeholders added or removed
+	MismatchOrder = "order" // same printf verbs, different argument order
+	MismatchVerb  = "verb"  // same printf arguments, different verb types
+)
+
+// Placeholder is a single interpolation token found in a translation value.
+type Placeholder struct {
+	Kind string
+	// Name is the verb letter for printf placeholders and the trimmed token
+	// content for template and brace placeholders.
+	Name string
+	// Arg is the 1-based argument number a printf verb consumes.
+	Arg int
+}
+
+func (p Placeholder) String() string {
+	switch p.Kind {
+	case PlaceholderPrintf:
+		return fmt.Sprintf("%%[%d]%s", p.Arg, p.Name)
+	case PlaceholderTemplate:
+		return "{{" + p.Name + "}}"
+	default:
+		return "{" + p.Name + "}"
+	}
+}
+
+// PlaceholderMismatch describes a value whose placeholders differ from the reference language.
+type PlaceholderMismatch struct {
+	Key       string
+	Language  string
+	Reference string
+	Reason    string
+	Expected  []string
+	Found     []string
+}
+
+var (
+	printfRe   = regexp.MustCompile(`%(?:%|[+\-#0]*(\[\d+\])?(?:\d+|\*)?(?:\.(?:\d+|\*)?)?(\[\d+\])?([a-zA-Z]))`)
+	templateRe = regexp.MustCompile(`\{\{-?\s*(.*?)\s*-?\}\}`)
+	braceRe    = regexp.MustCompile(`\{\s*([A-Za-z_][\w.]*)\s*\}`)
+)
+
+// extractPlaceholders parses printf verbs, template actions and {name} tokens from s.
+func extractPlaceholders(s string) []Placeholder {
+	var out []Placeholder
+
+	arg := 0
+	for _, m := range printfRe.FindAllStringSubmatch(s, -1) {
+		if m[0] == "%%" {
+			continue
+		}
+		// an explicit index resets the implicit argument counter, as in fmt
+		for _, idx := range []string{m[1], m[2]} {
+			if idx != "" {
+				n, _ := strconv.Atoi(strings.Trim(idx, "[]"))
+				arg = n - 1
+			}
+		}
+		arg++
+		out = append(out, Placeholder{Kind: PlaceholderPrintf, Name: m[3], Arg: arg})
+	}
+
+	for _, m := range templateRe.FindAllStringSubmatch(s, -1) {
+		out = append(out, Placeholder{Kind: PlaceholderTemplate, Name: m[1]})
+	}
+
+	// blank out template actions so their braces are not seen as {name} tokens
+	rest := templateRe.ReplaceAllString(s, "")
+	for _, m := range braceRe.FindAllStringSubmatch(rest, -1) {
+		out = append(out, Placeholder{Kind: PlaceholderBrace, Name: m[1]})
+	}
+
+	return out
+}
+
+// ReferenceLanguage returns the language placeholder checks compare against:
+// "en" when loaded, otherwise the first language in sorted order.
+func (tm *TranslationManager) ReferenceLanguage() string {
+	for _, lang := range tm.Languages {
+		if lang == "en" {
+			return lang
+		}
+	}
+	if len(tm.Languages) > 0 {
+		return tm.Languages[0]
+	}
+	return ""
+}
+
+// CheckPlaceholders compares the placeholders of every string value with the
+// value of the same key in the reference language. Printf verbs are positional,
+// so their order and verb types must match; named placeholders may be reordered
+// freely because word order differs between languages. ICU MessageFormat
+// values are left to CheckMessageFormat.
+func (tm *TranslationManager) CheckPlaceholders(reference string) []PlaceholderMismatch {
+	mismatches := make([]PlaceholderMismatch, 0)
+	refFlat := tm.flattenKeys("", tm.data[reference])
+	icu := tm.icuKeys()
+
+	refKeys := make([]string, 0, len(refFlat))
+	for key := range refFlat {
+		if icu[key] {
+			continue
+		}
+		refKeys = append(refKeys, key)
+	}
+	sort.Strings(refKeys)
+
+	for _, lang := range tm.Languages {
+		if lang == reference {
+			continue
+		}
+		flat := tm.flattenKeys("", tm.data[lang])
+
+		for _, key := range refKeys {
+			refVal, ok := refFlat[key].(string)
+			if !ok {
+				continue
+			}
+			val, ok := flat[key].(string)
+			if !ok {
+				continue
+			}
+
+			want := extractPlaceholders(refVal)
+			got := extractPlaceholders(val)
+			if reason := comparePlaceholders(want, got); reason != "" {
+				mismatches = append(mismatches, PlaceholderMismatch{
+					Key:       key,
+					Language:  lang,
+					Reference: reference,
+					Reason:    reason,
+					Expected:  placeholderStrings(want),
+					Found:     placeholderStrings(got),
+				})
+			}
+		}
+	}
+
+	return mismatches
+}
+
+// comparePlaceholders returns the mismatch reason or "" when both sets agree.
+func comparePlaceholders(want, got []Placeholder) string {
+	wantArgs, wantNamed := splitPlaceholders(want)
+	gotArgs, gotNamed := splitPlaceholders(got)
+
+	if !equalStringSets(wantNamed, gotNamed) {
+		return MismatchSet
+	}
+
+	if len(wantArgs) != len(gotArgs) {
+		return MismatchSet
+	}
+	differs := false
+	for arg, verb := range wantArgs {
+		other, ok := gotArgs[arg]
+		if !ok {
+			return MismatchSet
+		}
+		if other != verb {
+			differs = true
+		}
+	}
+	if !differs {
+		return ""
+	}
+
+	if equalStringSets(mapValues(wantArgs), mapValues(gotArgs)) {
+		return MismatchOrder
+	}
+	return MismatchVerb
+}
+
+// splitPlaceholders returns printf verbs keyed by argument number and the named tokens.
+func splitPlaceholders(ps []Placeholder) (map[int]string, []string) {
+	args := make(map[int]string)
+	named := make([]string, 0)
+	for _, p := range ps {
+		if p.Kind == PlaceholderPrintf {
+			args[p.Arg] = p.Name
+		} else {
+			named = append(named, p.String())
+		}
+	}
+	return args, named
+}
+
+// equalStringSets reports whether a and b contain the same elements with the same multiplicity.
+func equalStringSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		counts[s]--
+		if counts[s] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func mapValues(m map[int]string) []string {
+	out := make([]string, 0, len(m))
+	for _, v := range m {
+		out = append(out, v)
+	}
+	return out
+}
+
+func placeholderStrings(ps []Placeholder) []string {
+	out := make([]string, 0, len(ps))
+	for _, p := range ps {
+		out = append(out, p.String())
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractPlaceholders_Kinds(t *testing.T) {
+	got := placeholderStrings(extractPlaceholders("Hi {{.Name}}, {count} new, %d of %[1]s, 100%% done, {{ user }}"))
+
+	want := []string{"%[1]d", "%[1]s", "{{.Name}}", "{{user}}", "{count}"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("placeholder mismatch\nwant: %v\ngot:  %v", want, got)
+	}
+}
+
+func TestCheckPlaceholders_Reasons(t *testing.T) {
+	tm := &TranslationManager{
+		data: map[string]map[string]interface{}{
+			"en": {
+				"count":   "Found %d missing in %s",
+				"greet":   "Hello {{.Name}}",
+				"order":   "%s has %d items",
+				"named":   "{a} and {b}",
+				"indexed": "%[1]s has %[2]d items",
+				"ok":      "Plain text",
+			},
+			"de": {
+				"count":   "%d fehlen",
+				"greet":   "Hallo",
+				"order":   "%d Einträge hat %s",
+				"named":   "{b} und {a}",
+				"indexed": "%[2]d Einträge hat %[1]s",
+				"ok":      "Nur Text",
+			},
+			"fr": {
+				"count": "Trouvé %s manquants dans %s",
+			},
+		},
+		Languages: []string{"de", "en", "fr"},
+	}
+
+	got := make(map[string]string)
+	for _, m := range tm.CheckPlaceholders(tm.ReferenceLanguage()) {
+		got[m.Language+":"+m.Key] = m.Reason
+	}
+
+	want := map[string]string{
+		"de:count": MismatchSet,
+		"de:greet": MismatchSet,
+		"de:order": MismatchOrder,
+		"fr:count": MismatchVerb,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("mismatch reasons\nwant: %v\ngot:  %v", want, got)
+	}
+}
@@ -0,0 +1,190 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mlechner911/i18ntool/internal/plural"
+)
+
+// PluralIssue is an i18next plural key (item_one, item_other, ...) whose forms
+// in one language do not match the CLDR categories of that language.
+type PluralIssue struct {
+	Key      string   `json:"key"` // base key, e.g. "item"
+	Language string   `json:"language"`
+	File     string   `json:"file"`
+	Missing  []string `json:"missing,omitempty"`  // categories the language requires but lacks
+	Rare     []string `json:"rare,omitempty"`     // lacking categories only large numbers or fractions select
+	Unused   []string `json:"unused,omitempty"`   // forms for categories the language does not use
+	NoRules  bool     `json:"no_rules,omitempty"` // the language has no embedded rules; Key is empty
+}
+
+// languageCodeRe matches names that look like a language tag rather than a
+// file name the language was taken from.
+var languageCodeRe = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]+)*$`)
+
+// pluralBases returns the base keys that have plural forms in any language.
+// A base that is also a plain key (item next to item_one) is not a plural.
+func (tm *TranslationManager) pluralBases() map[string]bool {
+	bases := make(map[string]bool)
+	for _, lang := range tm.Languages {
+		flat := tm.flattenKeys("", tm.data[lang])
+		for key := range flat {
+			if base, _, ok := splitPluralKey(key); ok {
+				if _, taken := flat[base]; !taken {
+					bases[base] = true
+				}
+			}
+		}
+	}
+	return bases
+}
+
+// isPluralForm reports whether key is a plural form of one of bases.
+func isPluralForm(key string, bases map[string]bool) bool {
+	base, _, ok := splitPluralKey(key)
+	return ok && bases[base]
+}
+
+// pluralForms returns the categories base has a non-null form for in flat.
+func pluralForms(flat map[string]interface{}, base string) map[string]interface{} {
+	forms := make(map[string]interface{})
+	for _, cat := range plural.Order {
+		if v, ok := flat[base+"_"+cat]; ok && v != nil {
+			forms[cat] = v
+		}
+	}
+	return forms
+}
+
+// commonCategories returns the categories some integer from 0 to 1000
+// selects, plus "other", which i18next falls back to. The rest, like the
+// "many" French uses from 1,000,000 on, only apply to large numbers or
+// fractions.
+func commonCategories(rules *plural.Rules) map[string]bool {
+	common := map[string]bool{"other": true}
+	for n := 0; n <= 1000; n++ {
+		common[rules.Select(n)] = true
+	}
+	return common
+}
+
+// CheckPlurals compares the plural forms of every plural key with the CLDR
+// rules of each language: a language lacking the key entirely misses all its
+// categories. Lacking categories outside commonCategories are reported as
+// Rare. A language code without embedded rules yields one NoRules issue when
+// there are plural keys; other names (e.g. "file-1") are skipped.
+func (tm *TranslationManager) CheckPlurals() []PluralIssue {
+	bases := make([]string, 0)
+	for base := range tm.pluralBases() {
+		bases = append(bases, base)
+	}
+	sort.Strings(bases)
+
+	issues := make([]PluralIssue, 0)
+	for _, lang := range tm.Languages {
+		rules, ok := plural.For(lang)
+		if !ok {
+			if len(bases) > 0 && languageCodeRe.MatchString(lang) {
+				issues = append(issues, PluralIssue{Language: lang, File: tm.files[lang], NoRules: true})
+			}
+			continue
+		}
+		required := rules.Categories()
+		common := commonCategories(rules)
+		flat := tm.flattenKeys("", tm.data[lang])
+		for _, base := range bases {
+			forms := pluralForms(flat, base)
+			issue := PluralIssue{Key: base, Language: lang, File: tm.files[lang]}
+			for _, cat := range required {
+				if _, ok := forms[cat]; ok {
+					continue
+				}
+				if common[cat] {
+					issue.Missing = append(issue.Missing, cat)
+				} else {
+					issue.Rare = append(issue.Rare, cat)
+				}
+			}
+			for _, cat := range plural.Order {
+				if _, ok := forms[cat]; ok && !containsString(required, cat) {
+					issue.Unused = append(issue.Unused, cat)
+				}
+			}
+			if len(issue.Missing) > 0 || len(issue.Rare) > 0 || len(issue.Unused) > 0 {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Key < issues[j].Key })
+	return issues
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// PluralIssues converts CheckPlurals results into report issues: missing
+// forms are errors; rare missing forms and forms the language never selects
+// are warnings.
+func (tm *TranslationManager) PluralIssues(plurals []PluralIssue) []Issue {
+	issues := make([]Issue, 0)
+	for _, p := range plurals {
+		if p.NoRules {
+			issues = append(issues, Issue{
+				Rule:     RulePluralNoRules,
+				Level:    "warning",
+				Language: p.Language,
+				File:     p.File,
+				Message:  fmt.Sprintf("no CLDR plural rules for %s, so its plural forms are not checked", p.Language),
+			})
+		}
+		if len(p.Missing) > 0 {
+			issues = append(issues, Issue{
+				Rule:     RulePluralMissing,
+				Level:    "error",
+				Key:      p.Key,
+				Language: p.Language,
+				File:     p.File,
+				Message:  fmt.Sprintf("plural key %q lacks forms %s required by %s", p.Key, suffixList(p.Key, p.Missing), p.Language),
+			})
+		}
+		if len(p.Rare) > 0 {
+			issues = append(issues, Issue{
+				Rule:     RulePluralRare,
+				Level:    "warning",
+				Key:      p.Key,
+				Language: p.Language,
+				File:     p.File,
+				Message:  fmt.Sprintf("plural key %q lacks forms %s, which %s uses only for large numbers or fractions", p.Key, suffixList(p.Key, p.Rare), p.Language),
+			})
+		}
+		if len(p.Unused) > 0 {
+			issues = append(issues, Issue{
+				Rule:     RulePluralUnused,
+				Level:    "warning",
+				Key:      p.Key,
+				Language: p.Language,
+				File:     p.File,
+				Message:  fmt.Sprintf("plural key %q has forms %s that %s never uses", p.Key, suffixList(p.Key, p.Unused), p.Language),
+			})
+		}
+	}
+	return issues
+}
+
+// suffixList renders categories as the keys holding them: item_one, item_few.
+func suffixList(base string, categories []string) string {
+	keys := make([]string, len(categories))
+	for i, cat := range categories {
+		keys[i] = base + "_" + cat
+	}
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckPlurals(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"en": `{"item_one": "1 item", "item_other": "{{count}} items", "step_one": "1 step", "step_other": "n steps", "plain_one": "x", "plain": "y"}`,
+		"pl": `{"item_one": "1 plik", "item_few": "{{count}} pliki", "item_many": "{{count}} plików", "item_other": "{{count}} pliku", "step_one": "1 krok", "step_other": "n"}`,
+		"de": `{"item_one": "1 Eintrag", "item_few": "x", "item_other": "{{count}} Einträge", "step_one": "1 Schritt", "step_other": "n", "plain_one": "x", "plain": "y"}`,
+		"fr": `{"item_one": "1 élément", "item_other": "{{count}} éléments", "step_one": "1 étape", "step_other": "n", "plain_one": "x", "plain": "y"}`,
+	}
+	paths := map[string]string{}
+	for lang, content := range files {
+		paths[lang] = writeLocale(t, dir, lang+".json", content)
+	}
+	tm, err := NewTranslationManager(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []PluralIssue{
+		{Key: "item", Language: "de", File: paths["de"], Unused: []string{"few"}},
+		{Key: "item", Language: "fr", File: paths["fr"], Rare: []string{"many"}},
+		{Key: "step", Language: "fr", File: paths["fr"], Rare: []string{"many"}},
+		{Key: "step", Language: "pl", File: paths["pl"], Missing: []string{"few", "many"}},
+	}
+	if got := tm.CheckPlurals(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+	for _, issue := range tm.PluralIssues(want) {
+		if issue.Language == "fr" && (issue.Rule != RulePluralRare || issue.Level != "warning") {
+			t.Errorf("fr: want a %s warning, got %+v", RulePluralRare, issue)
+		}
+	}
+
+	// per-language plural forms are not missing translations; "plain" is no plural
+	for _, m := range tm.CheckMissing() {
+		if m.Key != "plain" && m.Key != "plain_one" {
+			t.Errorf("unexpected missing key %s", m.Key)
+		}
+	}
+
+	statuses, err := tm.CheckAgainstSource("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, st := range statuses {
+		if st.Language == "pl" && !reflect.DeepEqual(st.Untranslated, []string{"plain", "plain_one", "step"}) {
+			t.Errorf("pl: unexpected untranslated %v", st.Untranslated)
+		}
+		if st.Language == "de" && (st.Total != 4 || st.Translated != 4) {
+			t.Errorf("de: expected 4/4 translated, got %+v", st)
+		}
+	}
+}
+
+func TestCheckPlurals_ReportsLanguagesWithoutRules(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"item_one": "1", "item_other": "n"}`
+	paths := map[string]string{
+		"en":       writeLocale(t, dir, "en.json", content),
+		"yo":       writeLocale(t, dir, "yo.json", content),
+		"messages": writeLocale(t, dir, "messages.json", content),
+	}
+	tm, err := NewTranslationManager(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []PluralIssue{{Language: "yo", File: paths["yo"], NoRules: true}}
+	got := tm.CheckPlurals()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+	if issues := tm.PluralIssues(got); len(issues) != 1 || issues[0].Rule != RulePluralNoRules || issues[0].Level != "warning" {
+		t.Fatalf("unexpected issues %+v", issues)
+	}
+}
@@ -0,0 +1,762 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mlechner911/i18ntool/internal/plural"
+)
+
+// jsonValueFlag marks PO entries whose msgstr holds a JSON-encoded non-string leaf.
+const jsonValueFlag = "json-value"
+
+// emptyValueFlag marks PO entries whose empty msgstr stands for an empty JSON
+// string rather than a missing translation.
+const emptyValueFlag = "empty-value"
+
+// POEntry is a single gettext message.
+type POEntry struct {
+	TranslatorComments []string // "# " lines
+	ExtractedComments  []string // "#." lines
+	References         []string // "#:" source references
+	Flags              []string // "#," flags such as fuzzy or c-format
+	Previous           []string // "#|" lines, kept verbatim
+	Context            string   // msgctxt
+	ID                 string
+	IDPlural           string
+	Str                string
+	StrPlural          []string
+}
+
+// POFile is a parsed .po or .pot file. The header is the msgstr of the entry
+// with an empty msgid; obsolete "#~" entries are kept verbatim.
+type POFile struct {
+	HeaderComments []string
+	Header         string
+	Entries        []*POEntry
+	Obsolete       []string
+}
+
+// Fuzzy reports whether the entry carries the fuzzy flag.
+func (e *POEntry) Fuzzy() bool {
+	return e.hasFlag("fuzzy")
+}
+
+// Plural reports whether the entry has plural forms. msgid_plural may be
+// empty when the source has no value yet.
+func (e *POEntry) Plural() bool {
+	return e.IDPlural != "" || len(e.StrPlural) > 0
+}
+
+func (e *POEntry) hasFlag(flag string) bool {
+	for _, f := range e.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// Key returns the dotted translation key of the entry: msgctxt, or msgid when
+// the file was not produced by this tool.
+func (e *POEntry) Key() string {
+	if e.Context != "" {
+		return e.Context
+	}
+	return e.ID
+}
+
+// HeaderField returns the value of a "Name: value" line from the header.
+func (f *POFile) HeaderField(name string) string {
+	for _, line := range strings.Split(f.Header, "\n") {
+		if k, v, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(k), name) {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// ParsePO reads a gettext .po/.pot file.
+func ParsePO(r io.Reader) (*POFile, error) {
+	f := &POFile{}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var cur *POEntry
+	var target *string // string receiving continuation lines
+	inStr := false     // true once the current entry has seen a msgstr
+	headerDone := false
+	lineNo := 0
+
+	flush := func() {
+		if cur == nil {
+			return
+		}
+		if !headerDone && cur.ID == "" && cur.Context == "" {
+			f.HeaderComments = cur.TranslatorComments
+			f.Header = cur.Str
+		} else {
+			f.Entries = append(f.Entries, cur)
+		}
+		headerDone = true
+		cur = nil
+		target = nil
+		inStr = false
+	}
+	entry := func() *POEntry {
+		if cur == nil || inStr {
+			flush()
+			cur = &POEntry{}
+		}
+		return cur
+	}
+
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimSpace(sc.Text())
+
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#~"):
+			flush()
+			f.Obsolete = append(f.Obsolete, sc.Text())
+		case strings.HasPrefix(line, "#:"):
+			e := entry()
+			e.References = append(e.References, strings.Fields(line[2:])...)
+		case strings.HasPrefix(line, "#,"):
+			e := entry()
+			for _, flag := range strings.Split(line[2:], ",") {
+				if flag = strings.TrimSpace(flag); flag != "" {
+					e.Flags = append(e.Flags, flag)
+				}
+			}
+		case strings.HasPrefix(line, "#."):
+			e := entry()
+			e.ExtractedComments = append(e.ExtractedComments, strings.TrimSpace(line[2:]))
+		case strings.HasPrefix(line, "#|"):
+			e := entry()
+			e.Previous = append(e.Previous, strings.TrimSpace(line[2:]))
+		case strings.HasPrefix(line, "#"):
+			e := entry()
+			e.TranslatorComments = append(e.TranslatorComments, strings.TrimPrefix(strings.TrimPrefix(line, "#"), " "))
+		case strings.HasPrefix(line, `"`):
+			if target == nil {
+				return nil, fmt.Errorf("line %d: string without keyword", lineNo)
+			}
+			s, err := unquotePO(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			*target += s
+		default:
+			keyword, rest, _ := strings.Cut(line, " ")
+			s, err := unquotePO(strings.TrimSpace(rest))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			switch {
+			case keyword == "msgctxt":
+				e := entry()
+				e.Context = s
+				target = &e.Context
+			case keyword == "msgid":
+				if cur != nil && (inStr || cur.ID != "") {
+					flush()
+				}
+				e := entry()
+				e.ID = s
+				target = &e.ID
+			case keyword == "msgid_plural":
+				if cur == nil {
+					return nil, fmt.Errorf("line %d: msgid_plural without msgid", lineNo)
+				}
+				cur.IDPlural = s
+				target = &cur.IDPlural
+			case keyword == "msgstr":
+				if cur == nil {
+					return nil, fmt.Errorf("line %d: msgstr without msgid", lineNo)
+				}
+				cur.Str = s
+				target = &cur.Str
+				inStr = true
+			case strings.HasPrefix(keyword, "msgstr["):
+				if cur == nil {
+					return nil, fmt.Errorf("line %d: msgstr without msgid", lineNo)
+				}
+				n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(keyword, "msgstr["), "]"))
+				if err != nil || n != len(cur.StrPlural) {
+					return nil, fmt.Errorf("line %d: unexpected %s", lineNo, keyword)
+				}
+				cur.StrPlural = append(cur.StrPlural, s)
+				target = &cur.StrPlural[n]
+				inStr = true
+			default:
+				return nil, fmt.Errorf("line %d: unknown keyword %q", lineNo, keyword)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return f, nil
+}
+
+// unquotePO decodes a C-style quoted PO string.
+func unquotePO(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("expected quoted string, got %q", s)
+	}
+	var b strings.Builder
+	body := s[1 : len(s)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(body) {
+			return "", fmt.Errorf("dangling escape in %q", s)
+		}
+		switch body[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '"', '\\':
+			b.WriteByte(body[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(body[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// quotePO encodes s as one or more PO string lines, splitting after newlines.
+func quotePO(keyword, s string) string {
+	esc := func(p string) string {
+		r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+		return `"` + r.Replace(p) + `"`
+	}
+	if !strings.Contains(strings.TrimSuffix(s, "\n"), "\n") {
+		return keyword + " " + esc(s) + "\n"
+	}
+	var b strings.Builder
+	b.WriteString(keyword + " \"\"\n")
+	for _, part := range strings.SplitAfter(s, "\n") {
+		if part != "" {
+			b.WriteString(esc(part) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// WritePO writes f in gettext format.
+func WritePO(w io.Writer, f *POFile) error {
+	var b strings.Builder
+	for _, c := range f.HeaderComments {
+		b.WriteString(strings.TrimRight("# "+c, " ") + "\n")
+	}
+	b.WriteString(quotePO("msgid", ""))
+	b.WriteString(quotePO("msgstr", f.Header))
+
+	for _, e := range f.Entries {
+		b.WriteString("\n")
+		for _, c := range e.TranslatorComments {
+			b.WriteString(strings.TrimRight("# "+c, " ") + "\n")
+		}
+		for _, c := range e.ExtractedComments {
+			b.WriteString("#. " + c + "\n")
+		}
+		if len(e.References) > 0 {
+			b.WriteString("#: " + strings.Join(e.References, " ") + "\n")
+		}
+		if len(e.Flags) > 0 {
+			b.WriteString("#, " + strings.Join(e.Flags, ", ") + "\n")
+		}
+		for _, p := range e.Previous {
+			b.WriteString("#| " + p + "\n")
+		}
+		if e.Context != "" {
+			b.WriteString(quotePO("msgctxt", e.Context))
+		}
+		b.WriteString(quotePO("msgid", e.ID))
+		if e.Plural() {
+			b.WriteString(quotePO("msgid_plural", e.IDPlural))
+			for i, s := range e.StrPlural {
+				b.WriteString(quotePO(fmt.Sprintf("msgstr[%d]", i), s))
+			}
+		} else {
+			b.WriteString(quotePO("msgstr", e.Str))
+		}
+	}
+
+	if len(f.Obsolete) > 0 {
+		b.WriteString("\n")
+		for _, line := range f.Obsolete {
+			b.WriteString(line + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// gettextPlural describes the Plural-Forms header of a language and the
+// plural category each msgstr[n] index stands for.
+type gettextPlural struct {
+	Expr       string
+	Categories []string
+}
+
+// gettextPlurals lists the Plural-Forms used by the gettext tool chain.
+var gettextPlurals = map[string]gettextPlural{
+	"en": {"nplurals=2; plural=(n != 1);", []string{"one", "other"}},
+	"de": {"nplurals=2; plural=(n != 1);", []string{"one", "other"}},
+	"es": {"nplurals=2; plural=(n != 1);", []string{"one", "other"}},
+	"it": {"nplurals=2; plural=(n != 1);", []string{"one", "other"}},
+	"nl": {"nplurals=2; plural=(n != 1);", []string{"one", "other"}},
+	"sv": {"nplurals=2; plural=(n != 1);", []string{"one", "other"}},
+	"fr": {"nplurals=2; plural=(n > 1);", []string{"one", "other"}},
+	"pt": {"nplurals=2; plural=(n > 1);", []string{"one", "other"}},
+	"ja": {"nplurals=1; plural=0;", []string{"other"}},
+	"ko": {"nplurals=1; plural=0;", []string{"other"}},
+	"zh": {"nplurals=1; plural=0;", []string{"other"}},
+	"ru": {"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);", []string{"one", "few", "many"}},
+	"uk": {"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);", []string{"one", "few", "many"}},
+	"pl": {"nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);", []string{"one", "few", "many"}},
+	"cs": {"nplurals=3; plural=(n==1) ? 0 : (n>=2 && n<=4) ? 1 : 2;", []string{"one", "few", "other"}},
+	"ar": {"nplurals=6; plural=(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n%100>=3 && n%100<=10 ? 3 : n%100>=11 ? 4 : 5);", []string{"zero", "one", "two", "few", "many", "other"}},
+}
+
+// defaultPluralCategories guesses the category order for languages missing
+// from gettextPlurals based on the number of forms.
+var defaultPluralCategories = map[int][]string{
+	1: {"other"},
+	2: {"one", "other"},
+	3: {"one", "few", "other"},
+	4: {"one", "two", "few", "other"},
+	5: {"one", "two", "few", "many", "other"},
+	6: {"zero", "one", "two", "few", "many", "other"},
+}
+
+var npluralsRe = regexp.MustCompile(`nplurals\s*=\s*(\d+)`)
+
+// pluralCategoriesFor returns the category of each msgstr[n] index for lang.
+func pluralCategoriesFor(lang string, nplurals int) []string {
+	if p, ok := gettextPlurals[baseLanguage(lang)]; ok && (nplurals == 0 || len(p.Categories) == nplurals) {
+		return p.Categories
+	}
+	if nplurals == 0 {
+		nplurals = 2
+	}
+	return defaultPluralCategories[nplurals]
+}
+
+// baseLanguage strips region subtags: "de-AT" and "de_AT" become "de".
+func baseLanguage(lang string) string {
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		return strings.ToLower(lang[:i])
+	}
+	return strings.ToLower(lang)
+}
+
+// splitPluralKey returns the base key and category of "item_one"-style keys.
+func splitPluralKey(key string) (string, string, bool) {
+	i := strings.LastIndex(key, "_")
+	if i <= 0 || !plural.IsCategory(key[i+1:]) {
+		return "", "", false
+	}
+	return key[:i], key[i+1:], true
+}
+
+// JSONToPO converts a nested translation map into a PO file. msgctxt holds the
+// dotted key and msgid the source language value; with a nil source the value
+// itself becomes the msgid. Templates (.pot) leave every msgstr empty.
+// i18next plural keys (item_one, item_other) become one msgid_plural entry.
+func (tm *TranslationManager) JSONToPO(data, source map[string]interface{}, lang string, template bool) (*POFile, error) {
+	flat := tm.flattenKeys("", data)
+	srcFlat := flat
+	if source != nil {
+		srcFlat = tm.flattenKeys("", source)
+	}
+
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	f := &POFile{Header: poHeader(lang, template)}
+	categories := pluralCategoriesFor(lang, 0)
+	groups, loose := pluralGroups(flat, categories, template)
+	done := make(map[string]bool)
+
+	for _, key := range keys {
+		if base, _, ok := splitPluralKey(key); ok && groups[base] != nil {
+			if !done[base] {
+				done[base] = true
+				f.Entries = append(f.Entries, pluralEntry(base, groups[base], srcFlat))
+			}
+			if !loose[key] {
+				continue
+			}
+		}
+
+		e := &POEntry{Context: key}
+		str, isString := flat[key].(string)
+		if !isString {
+			raw, err := json.Marshal(flat[key])
+			if err != nil {
+				return nil, fmt.Errorf("encoding %s: %w", key, err)
+			}
+			str = string(raw)
+			e.Flags = append(e.Flags, jsonValueFlag)
+		}
+		e.ID = str
+		if source != nil {
+			e.ID = leafString(srcFlat[key])
+		}
+		if !template {
+			e.Str = str
+			if isString && str == "" {
+				e.Flags = append(e.Flags, emptyValueFlag)
+			}
+		}
+		f.Entries = append(f.Entries, e)
+	}
+
+	return f, nil
+}
+
+// pluralGroup holds the msgstr[n] values of one msgid_plural entry.
+type pluralGroup struct {
+	forms []string
+	empty bool // empty forms are empty JSON strings, see emptyValueFlag
+}
+
+// pluralGroups collects the i18next plural keys that become msgid_plural
+// entries, by base key. Only forms of the language's categories with a
+// non-empty string value fill a msgstr[n]. The other forms are returned as
+// loose keys and exported as entries of their own, so nothing is lost: forms
+// of other categories (item_zero in German), non-string values, and empty
+// strings unless every category is present, in which case the group carries
+// emptyValueFlag instead.
+func pluralGroups(flat map[string]interface{}, categories []string, template bool) (map[string]*pluralGroup, map[string]bool) {
+	inCategory := make(map[string]bool, len(categories))
+	for _, cat := range categories {
+		inCategory[cat] = true
+	}
+	groups := make(map[string]*pluralGroup)
+	for key := range flat {
+		base, cat, ok := splitPluralKey(key)
+		if _, taken := flat[base]; ok && !taken && inCategory[cat] {
+			groups[base] = &pluralGroup{}
+		}
+	}
+
+	loose := make(map[string]bool)
+	for base, g := range groups {
+		var empties []string
+		absent := false
+		for _, cat := range categories {
+			key := base + "_" + cat
+			v, present := flat[key]
+			s, isString := v.(string)
+			switch {
+			case template:
+			case !present:
+				absent = true
+			case !isString:
+				absent = true
+				loose[key] = true
+			case s == "":
+				empties = append(empties, key)
+			}
+			if template || !isString {
+				s = ""
+			}
+			g.forms = append(g.forms, s)
+		}
+		if len(empties) > 0 && !absent {
+			g.empty = true
+			continue
+		}
+		for _, key := range empties {
+			loose[key] = true
+		}
+	}
+	for key := range flat {
+		if base, cat, ok := splitPluralKey(key); ok && groups[base] != nil && !inCategory[cat] {
+			loose[key] = true
+		}
+	}
+	return groups, loose
+}
+
+// pluralEntry builds the msgid_plural entry of a group; msgid and
+// msgid_plural are the one and other forms of the source.
+func pluralEntry(base string, g *pluralGroup, srcFlat map[string]interface{}) *POEntry {
+	one, other := leafString(srcFlat[base+"_one"]), leafString(srcFlat[base+"_other"])
+	if one == "" {
+		one = other
+	}
+	if other == "" {
+		other = one
+	}
+	e := &POEntry{Context: base, ID: one, IDPlural: other, StrPlural: g.forms}
+	if g.empty {
+		e.Flags = append(e.Flags, emptyValueFlag)
+	}
+	return e
+}
+
+// leafString returns string values as-is and encodes other leaves as JSON.
+func leafString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	raw, _ := json.Marshal(v)
+	return string(raw)
+}
+
+func poHeader(lang string, template bool) string {
+	var b strings.Builder
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\n")
+	if !template && lang != "" {
+		b.WriteString("Language: " + lang + "\n")
+	}
+	if p, ok := gettextPlurals[baseLanguage(lang)]; ok && !template {
+		b.WriteString("Plural-Forms: " + p.Expr + "\n")
+	} else {
+		b.WriteString("Plural-Forms: nplurals=2; plural=(n != 1);\n")
+	}
+	b.WriteString("X-Generator: i18n-manager\n")
+	return b.String()
+}
+
+// POToJSON converts a PO file into a nested translation map. Untranslated
+// entries are skipped unless useSource is set (as for .pot templates), in
+// which case the msgid becomes the value.
+func (tm *TranslationManager) POToJSON(f *POFile, useSource bool) (map[string]interface{}, error) {
+	doc, err := tm.poToDocument(f, useSource)
+	if err != nil {
+		return nil, err
+	}
+	return doc.toMap(), nil
+}
+
+// poToDocument converts a PO file into a sorted JSON document.
+func (tm *TranslationManager) poToDocument(f *POFile, useSource bool) (*jsonDocument, error) {
+	doc := newDocument()
+	lang := f.HeaderField("Language")
+	nplurals := 0
+	if m := npluralsRe.FindStringSubmatch(f.HeaderField("Plural-Forms")); m != nil {
+		nplurals, _ = strconv.Atoi(m[1])
+	}
+
+	set := func(key string, value *jsonNode) error {
+		if tm.keyExists(key, doc.root) {
+			return fmt.Errorf("duplicate key %q", key)
+		}
+		return tm.addNestedKey(doc.root, key, value)
+	}
+
+	// empty values of single entries may also be a form of a msgid_plural
+	// entry, which wins once translated
+	var empty []string
+
+	for _, e := range f.Entries {
+		key := e.Key()
+		keepEmpty := !useSource && e.hasFlag(emptyValueFlag)
+		if e.Plural() {
+			categories := pluralCategoriesFor(lang, nplurals)
+			forms := e.StrPlural
+			if useSource {
+				forms = []string{e.ID, e.IDPlural}
+				categories = []string{"one", "other"}
+			}
+			for i, s := range forms {
+				if (s == "" && !keepEmpty) || i >= len(categories) {
+					continue
+				}
+				if err := set(key+"_"+categories[i], stringNode(s)); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		str := e.Str
+		if str == "" && useSource {
+			str = e.ID
+		}
+		if str == "" {
+			if keepEmpty {
+				empty = append(empty, key)
+			}
+			continue
+		}
+		value := stringNode(str)
+		if e.hasFlag(jsonValueFlag) {
+			var err error
+			if value, err = parseNode([]byte(str), 0); err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", key, err)
+			}
+		}
+		if err := set(key, value); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range empty {
+		if !tm.keyExists(key, doc.root) {
+			if err := set(key, stringNode("")); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sortNode(doc.root)
+	return doc, nil
+}
+
+// mergePOMetadata copies comments, references and flags from an existing PO
+// file so re-exporting does not drop translator work. The fuzzy flag is only
+// kept while the translation is unchanged.
+func mergePOMetadata(f, old *POFile) {
+	byKey := make(map[string]*POEntry, len(old.Entries))
+	for _, e := range old.Entries {
+		byKey[e.Key()] = e
+	}
+	f.HeaderComments = old.HeaderComments
+	f.Obsolete = old.Obsolete
+
+	for _, e := range f.Entries {
+		prev, ok := byKey[e.Key()]
+		if !ok {
+			continue
+		}
+		e.TranslatorComments = prev.TranslatorComments
+		e.ExtractedComments = prev.ExtractedComments
+		e.References = prev.References
+		e.Previous = prev.Previous
+		unchanged := prev.Str == e.Str && strings.Join(prev.StrPlural, "\x00") == strings.Join(e.StrPlural, "\x00")
+		for _, flag := range prev.Flags {
+			if (flag == "fuzzy" && !unchanged) || e.hasFlag(flag) {
+				continue
+			}
+			e.Flags = append(e.Flags, flag)
+		}
+	}
+}
+
+// isPOFile reports whether path has a .po or .pot extension.
+func isPOFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".po" || ext == ".pot"
+}
+
+// ConvertFile converts between the JSON layout and gettext PO/POT in either
+// direction, chosen by the file extensions. When writing PO, sourcePath
+// provides the msgid values and metadata from an existing output file is
+// preserved. Existing output files are backed up first. It returns the path
+// written.
+func (tm *TranslationManager) ConvertFile(inPath, outPath, lang, sourcePath string) (string, error) {
+	switch {
+	case !isPOFile(inPath) && isPOFile(outPath):
+		data, err := readJSONFile(inPath)
+		if err != nil {
+			return "", err
+		}
+		var source map[string]interface{}
+		if sourcePath != "" {
+			if source, err = readJSONFile(sourcePath); err != nil {
+				return "", err
+			}
+		}
+		template := strings.EqualFold(filepath.Ext(outPath), ".pot")
+		f, err := tm.JSONToPO(data, source, lang, template)
+		if err != nil {
+			return "", err
+		}
+
+		if content, err := os.ReadFile(outPath); err == nil {
+			old, err := ParsePO(strings.NewReader(string(content)))
+			if err != nil {
+				return "", fmt.Errorf("parsing %s: %w", outPath, err)
+			}
+			mergePOMetadata(f, old)
+			if err := writeBackup(outPath, content); err != nil {
+				return "", err
+			}
+		}
+
+		out, err := os.Create(outPath)
+		if err != nil {
+			return "", fmt.Errorf("writing %s: %w", outPath, err)
+		}
+		err = WritePO(out, f)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", fmt.Errorf("writing %s: %w", outPath, err)
+		}
+
+	case isPOFile(inPath) && !isPOFile(outPath):
+		in, err := os.Open(inPath)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", inPath, err)
+		}
+		defer in.Close()
+		f, err := ParsePO(in)
+		if err != nil {
+			return "", fmt.Errorf("parsing %s: %w", inPath, err)
+		}
+		doc, err := tm.poToDocument(f, strings.EqualFold(filepath.Ext(inPath), ".pot"))
+		if err != nil {
+			return "", fmt.Errorf("converting %s: %w", inPath, err)
+		}
+		if err := backupIfExists(outPath); err != nil {
+			return "", err
+		}
+		if err := writeDocument(outPath, doc); err != nil {
+			return "", err
+		}
+
+	default:
+		return "", fmt.Errorf("cannot convert %s to %s: expected one JSON and one .po/.pot file", inPath, outPath)
+	}
+
+	return outPath, nil
+}
+
+// readJSONFile reads a nested JSON translation file.
+func readJSONFile(path string) (map[string]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	doc, err := parseDocument(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return doc.toMap(), nil
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPO_RoundTrip(t *testing.T) {
+	tm := &TranslationManager{}
+	data := map[string]interface{}{
+		"common": map[string]interface{}{
+			"save":  "Speichern",
+			"quote": "Sag \"Hallo\"\nzweite Zeile",
+		},
+		"item_one":   "{{.Count}} Eintrag",
+		"item_other": "{{.Count}} Einträge",
+		"limit":      json.Number("5"),
+	}
+
+	f, err := tm.JSONToPO(data, nil, "de", false)
+	if err != nil {
+		t.Fatalf("JSONToPO: %v", err)
+	}
+	var b strings.Builder
+	if err := WritePO(&b, f); err != nil {
+		t.Fatalf("WritePO: %v", err)
+	}
+	if !strings.Contains(b.String(), `msgid_plural "{{.Count}} Einträge"`) {
+		t.Fatalf("expected plural entry in output:\n%s", b.String())
+	}
+
+	parsed, err := ParsePO(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("ParsePO: %v\n%s", err, b.String())
+	}
+	got, err := tm.POToJSON(parsed, false)
+	if err != nil {
+		t.Fatalf("POToJSON: %v", err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Fatalf("round trip mismatch\nwant: %#v\ngot:  %#v", data, got)
+	}
+}
+
+func TestPO_RoundTripKeepsEmptyAndExtraForms(t *testing.T) {
+	tm := &TranslationManager{}
+	data := map[string]interface{}{
+		"empty":       "",
+		"item_zero":   "Keine Einträge",
+		"item_one":    "Ein Eintrag",
+		"item_other":  "{{.Count}} Einträge",
+		"box_one":     "",
+		"box_other":   "",
+		"bag_one":     "",
+		"count_other": json.Number("3"),
+	}
+
+	f, err := tm.JSONToPO(data, nil, "de", false)
+	if err != nil {
+		t.Fatalf("JSONToPO: %v", err)
+	}
+	var b strings.Builder
+	if err := WritePO(&b, f); err != nil {
+		t.Fatalf("WritePO: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{"msgctxt \"item_zero\"", "msgctxt \"item\"\nmsgid \"Ein Eintrag\"", "#, empty-value\nmsgctxt \"empty\""} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	parsed, err := ParsePO(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("ParsePO: %v\n%s", err, out)
+	}
+	got, err := tm.POToJSON(parsed, false)
+	if err != nil {
+		t.Fatalf("POToJSON: %v", err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Fatalf("round trip mismatch\nwant: %#v\ngot:  %#v\n%s", data, got, out)
+	}
+
+	// a translated plural form wins over the empty single entry of the same key
+	translated := strings.Replace(out, "msgctxt \"bag\"\nmsgid \"\"\nmsgid_plural \"\"\nmsgstr[0] \"\"", "msgctxt \"bag\"\nmsgid \"\"\nmsgid_plural \"\"\nmsgstr[0] \"Eine Tasche\"", 1)
+	if translated == out {
+		t.Fatalf("bag entry not found in output:\n%s", out)
+	}
+	if parsed, err = ParsePO(strings.NewReader(translated)); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = tm.POToJSON(parsed, false); err != nil {
+		t.Fatalf("POToJSON: %v", err)
+	}
+	if got["bag_one"] != "Eine Tasche" {
+		t.Fatalf("expected the translated plural form, got %#v", got["bag_one"])
+	}
+}
+
+func TestParsePO_Metadata(t *testing.T) {
+	src := `# Translator header
+msgid ""
+msgstr ""
+"Language: pl\n"
+"Plural-Forms: nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+# checked by Anna
+#. shown on the toolbar
+#: src/toolbar.go:12 src/menu.go:4
+#, fuzzy, c-format
+msgctxt "common.save"
+msgid "Save"
+msgstr "Zapisz"
+
+msgctxt "files"
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d plik"
+msgstr[1] "%d pliki"
+msgstr[2] "%d plików"
+`
+	f, err := ParsePO(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParsePO: %v", err)
+	}
+	if f.HeaderField("Language") != "pl" || len(f.Entries) != 2 {
+		t.Fatalf("unexpected header/entries: %q, %d", f.Header, len(f.Entries))
+	}
+	e := f.Entries[0]
+	if !e.Fuzzy() || e.Key() != "common.save" || len(e.References) != 2 ||
+		e.TranslatorComments[0] != "checked by Anna" || e.ExtractedComments[0] != "shown on the toolbar" {
+		t.Fatalf("metadata not parsed: %+v", e)
+	}
+
+	data, err := (&TranslationManager{}).POToJSON(f, false)
+	if err != nil {
+		t.Fatalf("POToJSON: %v", err)
+	}
+	if data["files_few"] != "%d pliki" || data["files_many"] != "%d plików" {
+		t.Fatalf("plural forms not mapped to categories: %#v", data)
+	}
+
+	var b strings.Builder
+	if err := WritePO(&b, f); err != nil {
+		t.Fatalf("WritePO: %v", err)
+	}
+	for _, want := range []string{"# checked by Anna\n", "#: src/toolbar.go:12 src/menu.go:4\n", "#, fuzzy, c-format\n", "msgstr[2] \"%d plików\"\n"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("expected %q in written PO:\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"fmt"
+	"path"
+)
+
+// PruneResult summarises a Prune run.
+type PruneResult struct {
+	Removed []string // keys removed from at least one language
+	Kept    []string // keys protected by a keep pattern
+	Files   []string // files that changed (or would change with dry-run)
+}
+
+// ValidKeyPatterns checks keep patterns; they use path.Match syntax against
+// the dotted key. Dots are not separators, so "status.*" matches every key
+// below status, however deep.
+func ValidKeyPatterns(patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid key pattern %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// matchKeyPattern reports whether key matches one of patterns.
+func matchKeyPattern(key string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Prune removes keys from every loaded language, skipping those matching a
+// keep pattern. Objects left empty are removed as well. Changed files are
+// backed up and written unless dryRun is set.
+func (tm *TranslationManager) Prune(keys []string, keep []string, dryRun bool) (*PruneResult, error) {
+	if err := ValidKeyPatterns(keep); err != nil {
+		return nil, err
+	}
+
+	result := &PruneResult{}
+	removed := make(map[string]bool)
+	for _, key := range keys {
+		if matchKeyPattern(key, keep) {
+			result.Kept = append(result.Kept, key)
+		}
+	}
+
+	for _, lang := range tm.Languages {
+		doc := tm.docs[lang]
+		changed := false
+		for _, key := range keys {
+			if matchKeyPattern(key, keep) {
+				continue
+			}
+			// a dry run leaves the loaded documents untouched
+			found := lookupNode(doc.root, key) != nil
+			if !dryRun {
+				found = removeNode(doc.root, key)
+			}
+			if found {
+				changed = true
+				removed[key] = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		result.Files = append(result.Files, tm.files[lang])
+		if dryRun {
+			continue
+		}
+		if err := tm.saveLanguage(lang, true); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range keys {
+		if removed[key] {
+			result.Removed = append(result.Removed, key)
+		}
+	}
+	return result, nil
+}
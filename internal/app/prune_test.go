@@ -0,0 +1,86 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	en := writeLocale(t, dir, "en.json", "{\n  \"flat.used\": \"x\",\n  \"old\": {\n    \"a\": \"1\",\n    \"b\": \"2\"\n  },\n  \"status\": {\n    \"open\": \"Open\"\n  }\n}\n")
+	de := writeLocale(t, dir, "de.json", "{\n  \"old\": {\n    \"a\": \"1\"\n  }\n}\n")
+
+	tm, err := NewTranslationManager(map[string]string{"en": en, "de": de})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []string{"old.a", "old.b", "status.open"}
+
+	result, err := tm.Prune(keys, []string{"status.*"}, true)
+	if err != nil {
+		t.Fatalf("Prune dry-run: %v", err)
+	}
+	if !reflect.DeepEqual(result.Removed, []string{"old.a", "old.b"}) || !reflect.DeepEqual(result.Kept, []string{"status.open"}) || len(result.Files) != 2 {
+		t.Fatalf("unexpected dry-run result %+v", result)
+	}
+	if got, _ := os.ReadFile(de); string(got) != "{\n  \"old\": {\n    \"a\": \"1\"\n  }\n}\n" {
+		t.Fatalf("dry-run modified %s:\n%s", de, got)
+	}
+
+	if _, err := tm.Prune(keys, []string{"status.*"}, false); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if got, _ := os.ReadFile(en); string(got) != "{\n  \"flat.used\": \"x\",\n  \"status\": {\n    \"open\": \"Open\"\n  }\n}\n" {
+		t.Fatalf("unexpected %s:\n%s", en, got)
+	}
+	if got, _ := os.ReadFile(de); string(got) != "{}\n" {
+		t.Fatalf("unexpected %s:\n%s", de, got)
+	}
+	if backups, _ := filepath.Glob(filepath.Join(dir, "*.backup.*")); len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %v", backups)
+	}
+
+	if _, err := tm.Prune(keys, []string{"["}, true); err == nil {
+		t.Fatalf("expected error for invalid keep pattern")
+	}
+}
+
+func TestPrune_KeepsPluralFormsAndObjectsUsedInCode(t *testing.T) {
+	dir := t.TempDir()
+	en := writeLocale(t, dir, "en.json", `{"item_one": "1 item", "item_other": "{{count}} items", "errors": {"a": "A", "b": "B"}, "old": "x"}`)
+	writeFile(t, dir, "app.js", "t('item', { count: n })\nt('errors', { returnObjects: true })\n")
+
+	tm, err := NewTranslationManager(map[string]string{"en": en})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unused, err := tm.FindUnusedKeys([]string{dir}, DefaultScanConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(unused, []string{"old"}) {
+		t.Fatalf("FindUnusedKeys = %v, want [old]", unused)
+	}
+	result, err := tm.Prune(unused, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Removed, []string{"old"}) {
+		t.Fatalf("Prune removed %v", result.Removed)
+	}
+}
+
+func TestRemoveNode_FlatDottedKey(t *testing.T) {
+	doc, err := parseDocument([]byte(`{"usage.title": "x", "usage": {"other": "y"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !removeNode(doc.root, "usage.title") || !removeNode(doc.root, "usage.other") {
+		t.Fatalf("expected both keys to be removed")
+	}
+	if got := string(doc.Bytes()); got != "{}" {
+		t.Fatalf("unexpected document %s", got)
+	}
+}
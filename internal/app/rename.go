@@ -0,0 +1,125 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// RenameKey moves the value or subtree at oldKey to newKey in every loaded
+// language. Nothing is written unless the move succeeds everywhere: an
+// existing newKey in any language is refused. Changed files are backed up
+// and the list of written files is returned.
+func (tm *TranslationManager) RenameKey(oldKey, newKey string) ([]string, error) {
+	if oldKey == newKey {
+		return nil, fmt.Errorf("old and new key are both '%s'", oldKey)
+	}
+	if strings.HasPrefix(newKey, oldKey+".") {
+		return nil, fmt.Errorf("cannot move '%s' into itself ('%s')", oldKey, newKey)
+	}
+
+	var langs []string
+	for _, lang := range tm.Languages {
+		root := tm.docs[lang].root
+		if tm.keyExists(newKey, root) {
+			return nil, fmt.Errorf("key '%s' already exists in %s", newKey, tm.files[lang])
+		}
+		if !tm.keyExists(oldKey, root) {
+			continue
+		}
+		// try the move on a copy so a blocked target leaves every file untouched
+		scratch := root.clone()
+		node := lookupNode(scratch, oldKey)
+		removeNode(scratch, oldKey)
+		if err := tm.addNestedKey(scratch, newKey, node); err != nil {
+			return nil, fmt.Errorf("%s: %w", tm.files[lang], err)
+		}
+		langs = append(langs, lang)
+	}
+	if len(langs) == 0 {
+		return nil, fmt.Errorf("key '%s' does not exist", oldKey)
+	}
+
+	written := make([]string, 0, len(langs))
+	for _, lang := range langs {
+		root := tm.docs[lang].root
+		node := lookupNode(root, oldKey).clone()
+		removeNode(root, oldKey)
+		if err := tm.addNestedKey(root, newKey, node); err != nil {
+			return nil, fmt.Errorf("%s: %w", tm.files[lang], err)
+		}
+		if err := tm.saveLanguage(lang, true); err != nil {
+			return nil, err
+		}
+		written = append(written, tm.files[lang])
+	}
+	return written, nil
+}
+
+// RenameSourceKeys rewrites the key literals found by the extractors that
+// name oldKey, or a key below it, to use newKey instead. Only references
+// whose source text is exactly the key are changed; escaped literals are
+// left alone. It returns the rewritten references with their old keys.
+func RenameSourceKeys(projectPaths []string, cfg ScanConfig, oldKey, newKey string) ([]KeyRef, error) {
+	refs, err := ScanSources(projectPaths, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	byFile := make(map[string][]KeyRef)
+	var files []string
+	for _, ref := range refs {
+		if ref.Key != oldKey && !strings.HasPrefix(ref.Key, oldKey+".") {
+			continue
+		}
+		if _, ok := byFile[ref.File]; !ok {
+			files = append(files, ref.File)
+		}
+		byFile[ref.File] = append(byFile[ref.File], ref)
+	}
+	sort.Strings(files)
+
+	var rewritten []KeyRef
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return rewritten, fmt.Errorf("reading %s: %w", file, err)
+		}
+		lineStarts := []int{0}
+		for i, c := range content {
+			if c == '\n' {
+				lineStarts = append(lineStarts, i+1)
+			}
+		}
+
+		// rewrite back to front so earlier offsets stay valid
+		fileRefs := byFile[file]
+		var done []KeyRef
+		for i := len(fileRefs) - 1; i >= 0; i-- {
+			ref := fileRefs[i]
+			if ref.Line < 1 || ref.Line > len(lineStarts) {
+				continue
+			}
+			start := lineStarts[ref.Line-1] + ref.Column - 1
+			end := start + len(ref.Key)
+			if end > len(content) || !bytes.Equal(content[start:end], []byte(ref.Key)) {
+				continue
+			}
+			replacement := newKey + strings.TrimPrefix(ref.Key, oldKey)
+			content = append(content[:start], append([]byte(replacement), content[end:]...)...)
+			done = append(done, ref)
+		}
+		if len(done) == 0 {
+			continue
+		}
+		if err := os.WriteFile(file, content, 0644); err != nil {
+			return rewritten, fmt.Errorf("writing %s: %w", file, err)
+		}
+		for i := len(done) - 1; i >= 0; i-- {
+			rewritten = append(rewritten, done[i])
+		}
+	}
+	return rewritten, nil
+}
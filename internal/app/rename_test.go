@@ -0,0 +1,77 @@
+package app
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRenameKey_MovesSubtreeInAllLocales(t *testing.T) {
+	dir := t.TempDir()
+	en := writeLocale(t, dir, "en.json", `{"common": {"button": {"save": "Save", "cancel": "Cancel"}}, "title": "T"}`)
+	de := writeLocale(t, dir, "de.json", `{"common": {"button": {"save": "Speichern"}, "other": "x"}}`)
+
+	tm, err := NewTranslationManager(map[string]string{"en": en, "de": de})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tm.RenameKey("common.button", "actions"); err != nil {
+		t.Fatalf("RenameKey: %v", err)
+	}
+
+	data, _ := readJSONFile(en)
+	flat := tm.flattenKeys("", data)
+	if flat["actions.save"] != "Save" || flat["actions.cancel"] != "Cancel" || flat["common.button.save"] != nil {
+		t.Fatalf("unexpected en content %v", flat)
+	}
+	if _, ok := data["common"]; ok {
+		t.Fatalf("expected empty common object to be removed")
+	}
+	data, _ = readJSONFile(de)
+	if flat := tm.flattenKeys("", data); flat["actions.save"] != "Speichern" || flat["common.other"] != "x" {
+		t.Fatalf("unexpected de content %v", flat)
+	}
+}
+
+func TestRenameKey_RefusesExistingTarget(t *testing.T) {
+	dir := t.TempDir()
+	en := writeLocale(t, dir, "en.json", `{"a": "1"}`)
+	de := writeLocale(t, dir, "de.json", `{"a": "1", "b": {"c": "2"}}`)
+
+	tm, err := NewTranslationManager(map[string]string{"en": en, "de": de})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, target := range []string{"b", "b.c.d", "a.x"} {
+		if _, err := tm.RenameKey("a", target); err == nil {
+			t.Errorf("expected rename to %q to fail", target)
+		}
+	}
+	if got, _ := os.ReadFile(en); string(got) != `{"a": "1"}` {
+		t.Fatalf("failed rename modified %s: %s", en, got)
+	}
+	if _, err := tm.RenameKey("missing", "z"); err == nil {
+		t.Errorf("expected error for a missing key")
+	}
+}
+
+func TestRenameSourceKeys(t *testing.T) {
+	dir := t.TempDir()
+	js := writeFile(t, dir, "app.js", "t('common.save'); t('common.save.hint'); t('common.saved')\nconst k = 'common.save'\n")
+	goFile := writeFile(t, dir, "main.go", "package main\n\nfunc f() { T(\"common.save\") }\n")
+
+	refs, err := RenameSourceKeys([]string{dir}, ScanConfig{}, "common.save", "actions.save")
+	if err != nil {
+		t.Fatalf("RenameSourceKeys: %v", err)
+	}
+	if len(refs) != 3 {
+		t.Fatalf("expected 3 rewritten references, got %+v", refs)
+	}
+	got, _ := os.ReadFile(js)
+	if want := "t('actions.save'); t('actions.save.hint'); t('common.saved')\nconst k = 'common.save'\n"; string(got) != want {
+		t.Fatalf("unexpected js:\n%s", got)
+	}
+	if got, _ := os.ReadFile(goFile); !strings.Contains(string(got), `T("actions.save")`) {
+		t.Fatalf("unexpected go:\n%s", got)
+	}
+}
@@ -0,0 +1,333 @@
+package app
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Output formats accepted by WriteReport.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+	FormatJUnit = "junit"
+)
+
+// Rule identifiers used in reports.
+const (
+	RuleMissing       = "missing-translation"
+	RulePlaceholder   = "placeholder-mismatch"
+	RuleUnused        = "unused-key"
+	RuleUndefined     = "undefined-key"
+	RuleUntranslated  = "untranslated"
+	RuleOrphaned      = "orphaned-key"
+	RuleTypeConflict  = "type-conflict"
+	RuleArrayValue    = "array-value"
+	RuleNonString     = "non-string-value"
+	RuleDuplicateKey  = "duplicate-key"
+	RuleInvalidJSON   = "invalid-json"
+	RulePluralMissing = "plural-missing"
+	RulePluralRare    = "plural-rare"
+	RulePluralUnused  = "plural-unused"
+	RulePluralNoRules = "plural-no-rules"
+	RuleICUSyntax     = "icu-syntax"
+	RuleICUArguments  = "icu-arguments"
+)
+
+// ruleDescriptions documents every rule for SARIF consumers.
+var ruleDescriptions = map[string]string{
+	RuleMissing:       "Key is missing or null in a language file",
+	RulePlaceholder:   "Placeholders differ from the reference language",
+	RuleUnused:        "Key is not referenced in project sources",
+	RuleUndefined:     "Key is referenced in project sources but defined in no language file",
+	RuleUntranslated:  "Key of the source language has no translation",
+	RuleOrphaned:      "Key does not exist in the source language",
+	RuleTypeConflict:  "Key is an object in one language file and a value in another",
+	RuleArrayValue:    "Value is an array instead of a translation string",
+	RuleNonString:     "Value is a number or boolean instead of a translation string",
+	RuleDuplicateKey:  "Key occurs more than once in the same object; only the last value is kept",
+	RuleInvalidJSON:   "File is not valid JSON",
+	RulePluralMissing: "Plural key lacks a form for a CLDR category the language requires",
+	RulePluralRare:    "Plural key lacks a form the language selects only for large numbers or fractions",
+	RulePluralUnused:  "Plural key has a form for a CLDR category the language never selects",
+	RulePluralNoRules: "Language has plural keys but no embedded CLDR plural rules",
+	RuleICUSyntax:     "Value is not valid ICU MessageFormat",
+	RuleICUArguments:  "MessageFormat arguments differ from the reference language",
+}
+
+// Issue is a single finding in a machine-readable report.
+type Issue struct {
+	Rule     string `json:"rule"`
+	Level    string `json:"level"`
+	Key      string `json:"key"`
+	Language string `json:"language,omitempty"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message"`
+}
+
+// Report groups the issues produced by one command run. Completion is set by
+// check --source and maps each target language to its completion percentage.
+type Report struct {
+	Command    string             `json:"command"`
+	Languages  []string           `json:"languages"`
+	Completion map[string]float64 `json:"completion,omitempty"`
+	Issues     []Issue            `json:"issues"`
+}
+
+// ValidFormat reports whether format is one of the supported output formats.
+func ValidFormat(format string) bool {
+	switch format {
+	case FormatText, FormatJSON, FormatSARIF, FormatJUnit:
+		return true
+	}
+	return false
+}
+
+// FilePath returns the file a language was loaded from.
+func (tm *TranslationManager) FilePath(lang string) string {
+	return tm.files[lang]
+}
+
+// MissingIssues converts CheckMissing results into report issues, one per missing language.
+func (tm *TranslationManager) MissingIssues(missing []MissingTranslation) []Issue {
+	issues := make([]Issue, 0)
+	for _, m := range missing {
+		for _, lang := range tm.Languages {
+			if m.Translations[lang] != "null" {
+				continue
+			}
+			issues = append(issues, Issue{
+				Rule:     RuleMissing,
+				Level:    "error",
+				Key:      m.Key,
+				Language: lang,
+				File:     tm.files[lang],
+				Message:  fmt.Sprintf("key %q is missing in %s", m.Key, lang),
+			})
+		}
+	}
+	return issues
+}
+
+// PlaceholderIssues converts CheckPlaceholders results into report issues.
+func (tm *TranslationManager) PlaceholderIssues(mismatches []PlaceholderMismatch) []Issue {
+	issues := make([]Issue, 0, len(mismatches))
+	for _, m := range mismatches {
+		issues = append(issues, Issue{
+			Rule:     RulePlaceholder,
+			Level:    "error",
+			Key:      m.Key,
+			Language: m.Language,
+			File:     tm.files[m.Language],
+			Message: fmt.Sprintf("placeholders of %q differ from %s (%s): expected [%s], found [%s]",
+				m.Key, m.Reference, m.Reason, strings.Join(m.Expected, " "), strings.Join(m.Found, " ")),
+		})
+	}
+	return issues
+}
+
+// UnusedIssues converts FindUnusedKeys results into report issues located in
+// the reference language file.
+func (tm *TranslationManager) UnusedIssues(keys []string) []Issue {
+	ref := tm.ReferenceLanguage()
+	issues := make([]Issue, 0, len(keys))
+	for _, key := range keys {
+		issues = append(issues, Issue{
+			Rule:     RuleUnused,
+			Level:    "warning",
+			Key:      key,
+			Language: ref,
+			File:     tm.files[ref],
+			Message:  fmt.Sprintf("key %q is not referenced in project sources", key),
+		})
+	}
+	return issues
+}
+
+// WriteReport writes r to w in the requested machine-readable format.
+func WriteReport(w io.Writer, format string, r Report) error {
+	if r.Issues == nil {
+		r.Issues = []Issue{}
+	}
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case FormatSARIF:
+		return writeSARIF(w, r)
+	case FormatJUnit:
+		return writeJUnit(w, r)
+	}
+	return fmt.Errorf("unsupported format %q", format)
+}
+
+// SARIF 2.1.0 subset understood by GitHub code scanning.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           *sarifRegion  `json:"region,omitempty"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func writeSARIF(w io.Writer, r Report) error {
+	ruleSet := make(map[string]bool)
+	results := make([]sarifResult, 0, len(r.Issues))
+	for _, is := range r.Issues {
+		ruleSet[is.Rule] = true
+		res := sarifResult{RuleID: is.Rule, Level: is.Level, Message: sarifMessage{Text: is.Message}}
+		if is.File != "" {
+			loc := sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: toSlash(is.File)}}
+			if is.Line > 0 {
+				loc.Region = &sarifRegion{StartLine: is.Line, StartColumn: is.Column}
+			}
+			res.Locations = []sarifLocation{{PhysicalLocation: loc}}
+		}
+		results = append(results, res)
+	}
+
+	ids := make([]string, 0, len(ruleSet))
+	for id := range ruleSet {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	rules := make([]sarifRule, 0, len(ids))
+	for _, id := range ids {
+		rules = append(rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: ruleDescriptions[id]}})
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "i18n-manager", Rules: rules}},
+			Results: results,
+		}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// toSlash turns Windows separators into URI separators.
+func toSlash(p string) string {
+	return strings.ReplaceAll(p, "\\", "/")
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit emits one failing test case per issue, or a single passing case
+// when the report is clean so CI dashboards still show the run.
+func writeJUnit(w io.Writer, r Report) error {
+	suite := junitSuite{Name: "i18n-manager " + r.Command}
+	for _, is := range r.Issues {
+		name := is.Key
+		if is.Language != "" && is.Key != "" {
+			name = is.Language + ": " + is.Key
+		} else if is.Language != "" {
+			name = is.Language
+		}
+		text := is.Message
+		if is.File != "" && is.Line > 0 {
+			text = fmt.Sprintf("%s:%d: %s", is.File, is.Line, text)
+		} else if is.File != "" {
+			text = is.File + ": " + text
+		}
+		suite.Cases = append(suite.Cases, junitCase{
+			ClassName: r.Command + "." + is.Rule,
+			Name:      name,
+			Failure:   &junitFailure{Message: is.Message, Type: is.Rule, Text: text},
+		})
+		suite.Failures++
+	}
+	if len(suite.Cases) == 0 {
+		suite.Cases = append(suite.Cases, junitCase{ClassName: r.Command, Name: r.Command})
+	}
+	suite.Tests = len(suite.Cases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitSuites{Suites: []junitSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
+
+func testReport() Report {
+	return Report{
+		Command:   "check",
+		Languages: []string{"de", "en"},
+		Issues: []Issue{
+			{Rule: RuleMissing, Level: "error", Key: "a.b", Language: "de", File: "locales/de.json", Message: "missing"},
+			{Rule: RuleUnused, Level: "warning", Key: "c", Language: "en", File: "locales/en.json", Line: 3, Message: "unused"},
+		},
+	}
+}
+
+func TestWriteReport_SARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, FormatSARIF, testReport()); err != nil {
+		t.Fatalf("WriteReport: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v\n%s", err, buf.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF header: %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || len(run.Results) != 2 {
+		t.Fatalf("expected 2 rules and 2 results, got %d and %d", len(run.Tool.Driver.Rules), len(run.Results))
+	}
+	if uri := run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "locales/de.json" {
+		t.Errorf("unexpected artifact uri %q", uri)
+	}
+	if run.Results[0].Locations[0].PhysicalLocation.Region != nil {
+		t.Errorf("expected no region when line is unknown")
+	}
+	if r := run.Results[1].Locations[0].PhysicalLocation.Region; r == nil || r.StartLine != 3 {
+		t.Errorf("expected region startLine 3, got %+v", r)
+	}
+}
+
+func TestWriteReport_JUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, FormatJUnit, testReport()); err != nil {
+		t.Fatalf("WriteReport: %v", err)
+	}
+
+	var suites junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("invalid JUnit XML: %v\n%s", err, buf.String())
+	}
+	s := suites.Suites[0]
+	if s.Tests != 2 || s.Failures != 2 {
+		t.Fatalf("expected 2 tests and 2 failures, got %d and %d", s.Tests, s.Failures)
+	}
+
+	buf.Reset()
+	if err := WriteReport(&buf, FormatJUnit, Report{Command: "unused"}); err != nil {
+		t.Fatalf("WriteReport: %v", err)
+	}
+	var clean junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &clean); err != nil {
+		t.Fatalf("invalid JUnit XML: %v", err)
+	}
+	if s := clean.Suites[0]; s.Tests != 1 || s.Failures != 0 {
+		t.Fatalf("expected a single passing case for a clean report, got %+v", s)
+	}
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mlechner911/i18ntool/internal/plural"
+)
+
+// LanguageStatus compares a target language with the source language.
+type LanguageStatus struct {
+	Language     string   `json:"language"`
+	Total        int      `json:"total"`        // keys in the source language
+	Translated   int      `json:"translated"`   // source keys with a value in the target
+	Untranslated []string `json:"untranslated"` // source keys missing, null or empty in the target
+	Orphaned     []string `json:"orphaned"`     // target keys that do not exist in the source
+}
+
+// Completion returns the translated share of the source keys in percent.
+func (s LanguageStatus) Completion() float64 {
+	if s.Total == 0 {
+		return 100
+	}
+	return 100 * float64(s.Translated) / float64(s.Total)
+}
+
+// CheckAgainstSource treats source as the authoritative language and returns
+// the status of every other language, in tm.Languages order. A target value
+// counts as untranslated when it is missing or null, or empty while the
+// source value is not. Keys involved in an object-vs-value conflict are left
+// to CheckStructure. Plural forms count as one key per base, translated when
+// the target has every form its CLDR rules require (the source's forms for
+// languages without rules).
+func (tm *TranslationManager) CheckAgainstSource(source string) ([]LanguageStatus, error) {
+	src, ok := tm.MatchLanguage(source)
+	if !ok {
+		return nil, fmt.Errorf("source language %q is not loaded", source)
+	}
+	srcFlat := tm.flattenKeys("", tm.data[src])
+	conflicts := tm.typeConflictRoots()
+	plurals := tm.pluralBases()
+	srcPlurals := make(map[string]map[string]interface{})
+	for key := range srcFlat {
+		if base, _, ok := splitPluralKey(key); ok && plurals[base] {
+			srcPlurals[base] = pluralForms(srcFlat, base)
+		}
+		if coveredBy(key, conflicts) || isPluralForm(key, plurals) {
+			delete(srcFlat, key)
+		}
+	}
+
+	statuses := make([]LanguageStatus, 0, len(tm.Languages)-1)
+	for _, lang := range tm.Languages {
+		if lang == src {
+			continue
+		}
+		flat := tm.flattenKeys("", tm.data[lang])
+		status := LanguageStatus{Language: lang, Total: len(srcFlat) + len(srcPlurals), Untranslated: []string{}, Orphaned: []string{}}
+		for key, srcVal := range srcFlat {
+			val := flat[key]
+			if val == nil || (val == "" && srcVal != "") {
+				status.Untranslated = append(status.Untranslated, key)
+				continue
+			}
+			status.Translated++
+		}
+		for base, srcForms := range srcPlurals {
+			required := plural.Categories(lang)
+			if required == nil {
+				required = make([]string, 0, len(srcForms))
+				for cat := range srcForms {
+					required = append(required, cat)
+				}
+			}
+			forms := pluralForms(flat, base)
+			translated := true
+			for _, cat := range required {
+				if v, ok := forms[cat]; !ok || v == "" {
+					translated = false
+				}
+			}
+			if !translated {
+				status.Untranslated = append(status.Untranslated, base)
+				continue
+			}
+			status.Translated++
+		}
+		for key := range flat {
+			if _, ok := srcFlat[key]; ok || coveredBy(key, conflicts) {
+				continue
+			}
+			if base, _, ok := splitPluralKey(key); ok && plurals[base] {
+				if _, ok := srcPlurals[base]; !ok && !containsString(status.Orphaned, base) {
+					status.Orphaned = append(status.Orphaned, base)
+				}
+				continue
+			}
+			status.Orphaned = append(status.Orphaned, key)
+		}
+		sort.Strings(status.Untranslated)
+		sort.Strings(status.Orphaned)
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// SourceIssues converts CheckAgainstSource results into report issues:
+// untranslated keys are errors, orphaned keys warnings.
+func (tm *TranslationManager) SourceIssues(source string, statuses []LanguageStatus) []Issue {
+	issues := make([]Issue, 0)
+	for _, s := range statuses {
+		for _, key := range s.Untranslated {
+			issues = append(issues, Issue{
+				Rule:     RuleUntranslated,
+				Level:    "error",
+				Key:      key,
+				Language: s.Language,
+				File:     tm.files[s.Language],
+				Message:  fmt.Sprintf("key %q of %s is not translated in %s", key, source, s.Language),
+			})
+		}
+		for _, key := range s.Orphaned {
+			issues = append(issues, Issue{
+				Rule:     RuleOrphaned,
+				Level:    "warning",
+				Key:      key,
+				Language: s.Language,
+				File:     tm.files[s.Language],
+				Message:  fmt.Sprintf("key %q exists in %s but not in the source language %s", key, s.Language, source),
+			})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckAgainstSource(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"en": `{"a": "A", "b": "B", "c": "C", "d": ""}`,
+		"de": `{"a": "A", "b": "", "c": null, "d": "", "x": "extra"}`,
+		"fr": `{"a": "A", "b": "B", "c": "C", "d": "D"}`,
+	}
+	paths := map[string]string{}
+	for lang, content := range files {
+		paths[lang] = writeLocale(t, dir, lang+".json", content)
+	}
+	tm, err := NewTranslationManager(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err := tm.CheckAgainstSource("EN")
+	if err != nil {
+		t.Fatalf("CheckAgainstSource: %v", err)
+	}
+	want := []LanguageStatus{
+		{Language: "de", Total: 4, Translated: 2, Untranslated: []string{"b", "c"}, Orphaned: []string{"x"}},
+		{Language: "fr", Total: 4, Translated: 4, Untranslated: []string{}, Orphaned: []string{}},
+	}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Fatalf("want %+v\ngot  %+v", want, statuses)
+	}
+	if c := statuses[0].Completion(); c != 50 {
+		t.Fatalf("expected 50%% completion for de, got %v", c)
+	}
+
+	issues := tm.SourceIssues("en", statuses)
+	if len(issues) != 3 || issues[0].Rule != RuleUntranslated || issues[2].Rule != RuleOrphaned || issues[2].Level != "warning" {
+		t.Fatalf("unexpected issues %+v", issues)
+	}
+
+	if _, err := tm.CheckAgainstSource("it"); err == nil {
+		t.Fatalf("expected error for an unknown source language")
+	}
+}
@@ -0,0 +1,186 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StructureLocation is the JSON type and path of a key in one language file.
+type StructureLocation struct {
+	Language string `json:"language"`
+	File     string `json:"file"`
+	Path     string `json:"path"` // JSONPath, e.g. $.user.profile or $["user.profile"]
+	Type     string `json:"type"` // object, string, number, boolean, array or null
+}
+
+// StructureConflict is a key whose shape is not a plain translation in every
+// file. Kind is one of RuleTypeConflict, RuleArrayValue and RuleNonString.
+// Locations lists the key in every language that has it.
+type StructureConflict struct {
+	Kind      string              `json:"kind"`
+	Key       string              `json:"key"`
+	Locations []StructureLocation `json:"locations"`
+}
+
+// structureEntry describes one node of a language file by its flattened key.
+type structureEntry struct {
+	path string
+	typ  string
+}
+
+var identRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// jsonType names the JSON type of a node.
+func jsonType(n *jsonNode) string {
+	if n.object != nil {
+		return "object"
+	}
+	switch n.value.(type) {
+	case string:
+		return "string"
+	case json.Number, float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	}
+	return "null"
+}
+
+// structureOf maps every flattened key of a document, objects included, to
+// its JSON path and type.
+func structureOf(root *jsonNode) map[string]structureEntry {
+	out := make(map[string]structureEntry)
+	var walk func(n *jsonNode, key, path string)
+	walk = func(n *jsonNode, key, path string) {
+		for _, m := range n.object.members {
+			childKey := m.key
+			if key != "" {
+				childKey = key + "." + m.key
+			}
+			childPath := path + "[" + strconv.Quote(m.key) + "]"
+			if identRe.MatchString(m.key) {
+				childPath = path + "." + m.key
+			}
+			out[childKey] = structureEntry{path: childPath, typ: jsonType(m.node)}
+			if m.node.object != nil {
+				walk(m.node, childKey, childPath)
+			}
+		}
+	}
+	walk(root, "", "$")
+	return out
+}
+
+// CheckStructure reports keys that are an object in one file and a value in
+// another, and values that are arrays, numbers or booleans instead of
+// strings. Null values are left to the missing-translation checks.
+func (tm *TranslationManager) CheckStructure() []StructureConflict {
+	structures := make(map[string]map[string]structureEntry, len(tm.Languages))
+	keySet := make(map[string]bool)
+	for _, lang := range tm.Languages {
+		root := nodeFromValue(tm.data[lang])
+		if doc := tm.docs[lang]; doc != nil {
+			root = doc.root
+		}
+		structures[lang] = structureOf(root)
+		for key := range structures[lang] {
+			keySet[key] = true
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	conflicts := make([]StructureConflict, 0)
+	for _, key := range keys {
+		var locations []StructureLocation
+		objects, leaves, arrays, others := 0, 0, 0, 0
+		for _, lang := range tm.Languages {
+			entry, ok := structures[lang][key]
+			if !ok {
+				continue
+			}
+			locations = append(locations, StructureLocation{Language: lang, File: tm.files[lang], Path: entry.path, Type: entry.typ})
+			switch entry.typ {
+			case "object":
+				objects++
+			case "null":
+			case "array":
+				arrays++
+				leaves++
+			case "number", "boolean":
+				others++
+				leaves++
+			default:
+				leaves++
+			}
+		}
+
+		switch {
+		case objects > 0 && leaves > 0:
+			conflicts = append(conflicts, StructureConflict{Kind: RuleTypeConflict, Key: key, Locations: locations})
+		case arrays > 0:
+			conflicts = append(conflicts, StructureConflict{Kind: RuleArrayValue, Key: key, Locations: locations})
+		case others > 0:
+			conflicts = append(conflicts, StructureConflict{Kind: RuleNonString, Key: key, Locations: locations})
+		}
+	}
+	return conflicts
+}
+
+// typeConflictRoots returns the keys that are an object in one file and a
+// value in another. Keys at or below them are skipped by CheckMissing and
+// CheckAgainstSource, which would otherwise report both halves as missing.
+func (tm *TranslationManager) typeConflictRoots() []string {
+	var roots []string
+	for _, c := range tm.CheckStructure() {
+		if c.Kind == RuleTypeConflict {
+			roots = append(roots, c.Key)
+		}
+	}
+	return roots
+}
+
+// coveredBy reports whether key equals or lies below one of roots.
+func coveredBy(key string, roots []string) bool {
+	for _, root := range roots {
+		if key == root || strings.HasPrefix(key, root+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// StructureIssues converts CheckStructure results into report issues, one
+// per file whose value causes the conflict.
+func (tm *TranslationManager) StructureIssues(conflicts []StructureConflict) []Issue {
+	issues := make([]Issue, 0)
+	for _, c := range conflicts {
+		parts := make([]string, 0, len(c.Locations))
+		for _, loc := range c.Locations {
+			parts = append(parts, fmt.Sprintf("%s in %s at %s", loc.Type, loc.Language, loc.Path))
+		}
+		for _, loc := range c.Locations {
+			if c.Kind != RuleTypeConflict && (loc.Type == "string" || loc.Type == "null") {
+				continue
+			}
+			issues = append(issues, Issue{
+				Rule:     c.Kind,
+				Level:    "error",
+				Key:      c.Key,
+				Language: loc.Language,
+				File:     loc.File,
+				Message:  fmt.Sprintf("key %q has conflicting structure: %s", c.Key, strings.Join(parts, ", ")),
+			})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckStructure(t *testing.T) {
+	dir := t.TempDir()
+	en := writeLocale(t, dir, "en.json", `{"user": {"profile": {"name": "Name"}}, "tags": ["a", "b"], "max": 5, "ok": "OK", "gone": null}`)
+	de := writeLocale(t, dir, "de.json", `{"user": {"profile": "Profil"}, "tags": "a, b", "max": "5", "ok": "OK", "gone": {"x": "y"}}`)
+	tm, err := NewTranslationManager(map[string]string{"en": en, "de": de})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conflicts := tm.CheckStructure()
+	got := map[string]string{}
+	for _, c := range conflicts {
+		got[c.Key] = c.Kind
+	}
+	want := map[string]string{"user.profile": RuleTypeConflict, "tags": RuleArrayValue, "max": RuleNonString}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	for _, c := range conflicts {
+		if c.Key != "user.profile" {
+			continue
+		}
+		wantLocs := []StructureLocation{
+			{Language: "de", File: de, Path: "$.user.profile", Type: "string"},
+			{Language: "en", File: en, Path: "$.user.profile", Type: "object"},
+		}
+		if !reflect.DeepEqual(c.Locations, wantLocs) {
+			t.Fatalf("unexpected locations %+v", c.Locations)
+		}
+	}
+
+	// the conflicting subtree is not reported as missing on either side
+	for _, m := range tm.CheckMissing() {
+		if coveredBy(m.Key, []string{"user.profile"}) {
+			t.Fatalf("conflicting key %q reported as missing", m.Key)
+		}
+	}
+
+	// only the offending file is flagged for array and non-string values
+	for _, is := range tm.StructureIssues(conflicts) {
+		if is.Rule != RuleTypeConflict && is.Language != "en" {
+			t.Fatalf("unexpected issue %+v", is)
+		}
+	}
+}
+
+func TestStructureOf_QuotesDottedNames(t *testing.T) {
+	doc, err := parseDocument([]byte(`{"usage.title": "x", "a": {"b-c": 1}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := structureOf(doc.root)
+	if p := s["usage.title"].path; p != `$["usage.title"]` {
+		t.Errorf("unexpected path %s", p)
+	}
+	if e := s["a.b-c"]; e.path != `$.a["b-c"]` || e.typ != "number" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+}
@@ -3,6 +3,7 @@ package app
 type TranslationManager struct {
 	files     map[string]string
 	data      map[string]map[string]interface{}
+	docs      map[string]*jsonDocument
 	Languages []string
 }
 
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FindUndefinedKeys scans project paths with the configured extractors and
+// returns every reference to a key that exists in none of the loaded
+// languages. A reference to an object counts as defined, as does a
+// reference to the base of i18next plural keys (item for item_one).
+func (tm *TranslationManager) FindUndefinedKeys(projectPaths []string, cfg ScanConfig) ([]KeyRef, error) {
+	refs, err := ScanSources(projectPaths, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	defined := make(map[string]bool)
+	for _, key := range tm.GetAllKeys() {
+		defined[key] = true
+		if base, _, ok := splitPluralKey(key); ok {
+			defined[base] = true
+		}
+		for i := strings.LastIndex(key, "."); i > 0; i = strings.LastIndex(key[:i], ".") {
+			defined[key[:i]] = true
+		}
+	}
+
+	undefined := make([]KeyRef, 0)
+	for _, ref := range refs {
+		if !defined[ref.Key] {
+			undefined = append(undefined, ref)
+		}
+	}
+	return undefined, nil
+}
+
+// UndefinedIssues converts FindUndefinedKeys results into report issues
+// located at the referencing source line.
+func UndefinedIssues(refs []KeyRef) []Issue {
+	issues := make([]Issue, 0, len(refs))
+	for _, ref := range refs {
+		issues = append(issues, Issue{
+			Rule:    RuleUndefined,
+			Level:   "error",
+			Key:     ref.Key,
+			File:    ref.File,
+			Line:    ref.Line,
+			Column:  ref.Column,
+			Message: fmt.Sprintf("key %q is used but not defined in any language file", ref.Key),
+		})
+	}
+	return issues
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeFile writes a source file or other non-locale fixture into dir and
+// returns its path.
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFindUndefinedKeys(t *testing.T) {
+	dir := t.TempDir()
+	en := writeLocale(t, dir, "en.json", `{"nav": {"home": "Home"}, "flat.key": "x", "item_one": "1 item", "item_other": "{{count}} items"}`)
+	de := writeLocale(t, dir, "de.json", `{"only": {"de": "nur deutsch"}}`)
+	src := "const a = t('nav.home')\n" +
+		"const b = t('nav')\n" +
+		"const c = t('flat.key')\n" +
+		"const d = t('item', { count: 2 })\n" +
+		"const e = t('only.de')\n" +
+		"  const f = t('nav.missing')\n"
+	writeFile(t, dir, "app.js", src)
+
+	tm, err := NewTranslationManager(map[string]string{"en": en, "de": de})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := tm.FindUndefinedKeys([]string{dir}, ScanConfig{})
+	if err != nil {
+		t.Fatalf("FindUndefinedKeys: %v", err)
+	}
+	want := []KeyRef{{Key: "nav.missing", File: filepath.Join(dir, "app.js"), Line: 6, Column: 16}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+
+	issues := UndefinedIssues(got)
+	if len(issues) != 1 || issues[0].Rule != RuleUndefined || issues[0].Line != 6 || issues[0].Column != 16 {
+		t.Fatalf("unexpected issues %+v", issues)
+	}
+}
@@ -0,0 +1,310 @@
+package app
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Supported XLIFF versions.
+const (
+	XLIFF12 = "1.2"
+	XLIFF20 = "2.0"
+)
+
+// XLIFFConflict describes an imported unit that was not applied.
+type XLIFFConflict struct {
+	Key      string
+	Reason   string
+	Current  string
+	Incoming string
+}
+
+// XLIFFImport summarises an ImportXLIFF run.
+type XLIFFImport struct {
+	Language  string
+	File      string
+	Applied   []string
+	Conflicts []XLIFFConflict
+}
+
+type xliff12Doc struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	Version string        `xml:"version,attr"`
+	Files   []xliff12File `xml:"file"`
+}
+
+type xliff12File struct {
+	Original       string        `xml:"original,attr"`
+	SourceLanguage string        `xml:"source-language,attr"`
+	TargetLanguage string        `xml:"target-language,attr"`
+	Datatype       string        `xml:"datatype,attr"`
+	Units          []xliff12Unit `xml:"body>trans-unit"`
+}
+
+type xliff12Unit struct {
+	ID     string       `xml:"id,attr"`
+	Source string       `xml:"source"`
+	Target *xliffTarget `xml:"target"`
+}
+
+type xliffTarget struct {
+	State string `xml:"state,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type xliff20Doc struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	Version string        `xml:"version,attr"`
+	SrcLang string        `xml:"srcLang,attr"`
+	TrgLang string        `xml:"trgLang,attr"`
+	Files   []xliff20File `xml:"file"`
+}
+
+type xliff20File struct {
+	ID    string        `xml:"id,attr"`
+	Units []xliff20Unit `xml:"unit"`
+}
+
+type xliff20Unit struct {
+	ID       string           `xml:"id,attr"`
+	Segments []xliff20Segment `xml:"segment"`
+}
+
+type xliff20Segment struct {
+	State  string  `xml:"state,attr,omitempty"`
+	Source string  `xml:"source"`
+	Target *string `xml:"target"`
+}
+
+// xliffUnit is the version independent view used by ImportXLIFF.
+type xliffUnit struct {
+	ID     string
+	Source string
+	Target *string
+}
+
+// ExportXLIFF writes one XLIFF file per target language into outDir, named
+// <lang>.xlf. The source language value becomes <source>; existing target
+// values become <target> marked translated, missing ones need translation.
+func (tm *TranslationManager) ExportXLIFF(source, version, outDir string) ([]string, error) {
+	if _, ok := tm.data[source]; !ok {
+		return nil, fmt.Errorf("source language %q is not loaded", source)
+	}
+	if version != XLIFF12 && version != XLIFF20 {
+		return nil, fmt.Errorf("unsupported XLIFF version %q (expected %s or %s)", version, XLIFF12, XLIFF20)
+	}
+
+	srcFlat := tm.flattenKeys("", tm.data[source])
+	keys := make([]string, 0, len(srcFlat))
+	for key, val := range srcFlat {
+		if _, ok := val.(string); ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	written := make([]string, 0, len(tm.Languages))
+	for _, lang := range tm.Languages {
+		if lang == source {
+			continue
+		}
+		flat := tm.flattenKeys("", tm.data[lang])
+
+		var doc interface{}
+		if version == XLIFF12 {
+			file := xliff12File{
+				Original:       filepath.Base(tm.files[lang]),
+				SourceLanguage: source,
+				TargetLanguage: lang,
+				Datatype:       "plaintext",
+			}
+			for _, key := range keys {
+				unit := xliff12Unit{ID: key, Source: srcFlat[key].(string), Target: &xliffTarget{State: "needs-translation"}}
+				if val, ok := flat[key].(string); ok && val != "" {
+					unit.Target = &xliffTarget{State: "translated", Value: val}
+				}
+				file.Units = append(file.Units, unit)
+			}
+			doc = xliff12Doc{Xmlns: "urn:oasis:names:tc:xliff:document:1.2", Version: XLIFF12, Files: []xliff12File{file}}
+		} else {
+			file := xliff20File{ID: strings.TrimSuffix(filepath.Base(tm.files[lang]), filepath.Ext(tm.files[lang]))}
+			for _, key := range keys {
+				seg := xliff20Segment{State: "initial", Source: srcFlat[key].(string)}
+				if val, ok := flat[key].(string); ok && val != "" {
+					seg.State = "translated"
+					seg.Target = &val
+				}
+				file.Units = append(file.Units, xliff20Unit{ID: key, Segments: []xliff20Segment{seg}})
+			}
+			doc = xliff20Doc{Xmlns: "urn:oasis:names:tc:xliff:document:2.0", Version: XLIFF20, SrcLang: source, TrgLang: lang, Files: []xliff20File{file}}
+		}
+
+		content, err := xml.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encoding XLIFF for %s: %w", lang, err)
+		}
+		path := filepath.Join(outDir, lang+".xlf")
+		if err := backupIfExists(path); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, append([]byte(xml.Header), append(content, '\n')...), 0644); err != nil {
+			return nil, fmt.Errorf("writing %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+// parseXLIFF reads an XLIFF 1.2 or 2.0 document and returns its source and target language and units.
+func parseXLIFF(content []byte) (string, string, []xliffUnit, error) {
+	var head struct {
+		Version string `xml:"version,attr"`
+	}
+	if err := xml.Unmarshal(content, &head); err != nil {
+		return "", "", nil, err
+	}
+
+	var units []xliffUnit
+	switch head.Version {
+	case XLIFF12:
+		var doc xliff12Doc
+		if err := xml.Unmarshal(content, &doc); err != nil {
+			return "", "", nil, err
+		}
+		if len(doc.Files) == 0 {
+			return "", "", nil, fmt.Errorf("no <file> element")
+		}
+		for _, f := range doc.Files {
+			for _, u := range f.Units {
+				unit := xliffUnit{ID: u.ID, Source: u.Source}
+				if u.Target != nil {
+					val := u.Target.Value
+					unit.Target = &val
+				}
+				units = append(units, unit)
+			}
+		}
+		return doc.Files[0].SourceLanguage, doc.Files[0].TargetLanguage, units, nil
+
+	case XLIFF20:
+		var doc xliff20Doc
+		if err := xml.Unmarshal(content, &doc); err != nil {
+			return "", "", nil, err
+		}
+		for _, f := range doc.Files {
+			for _, u := range f.Units {
+				// multi-segment units are joined back into one value
+				unit := xliffUnit{ID: u.ID}
+				var target strings.Builder
+				hasTarget := false
+				for _, s := range u.Segments {
+					unit.Source += s.Source
+					if s.Target != nil {
+						hasTarget = true
+						target.WriteString(*s.Target)
+					}
+				}
+				if hasTarget {
+					val := target.String()
+					unit.Target = &val
+				}
+				units = append(units, unit)
+			}
+		}
+		return doc.SrcLang, doc.TrgLang, units, nil
+	}
+
+	return "", "", nil, fmt.Errorf("unsupported XLIFF version %q", head.Version)
+}
+
+// MatchLanguage finds the loaded language for a language code, ignoring case and "_" vs "-".
+func (tm *TranslationManager) MatchLanguage(code string) (string, bool) {
+	norm := func(s string) string { return strings.ToLower(strings.ReplaceAll(s, "_", "-")) }
+	for _, lang := range tm.Languages {
+		if norm(lang) == norm(code) {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// ImportXLIFF merges the targets of an XLIFF file into the matching language
+// file. Units whose target would overwrite a different existing value, or
+// whose source text changed since the export, are reported as conflicts and
+// only applied with force. The language file is backed up before writing.
+func (tm *TranslationManager) ImportXLIFF(path string, force bool) (*XLIFFImport, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	srcLang, trgLang, units, err := parseXLIFF(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	lang, ok := tm.MatchLanguage(trgLang)
+	if !ok {
+		return nil, fmt.Errorf("%s: target language %q is not loaded", path, trgLang)
+	}
+	source, hasSource := tm.MatchLanguage(srcLang)
+
+	result := &XLIFFImport{Language: lang, File: tm.files[lang]}
+	doc := tm.docs[lang]
+	flat := tm.flattenKeys("", tm.data[lang])
+	var srcFlat map[string]interface{}
+	if hasSource {
+		srcFlat = tm.flattenKeys("", tm.data[source])
+	}
+
+	for _, u := range units {
+		if u.Target == nil || *u.Target == "" {
+			continue
+		}
+		incoming := *u.Target
+		current, exists := flat[u.ID]
+		currentStr := fmt.Sprintf("%v", current)
+		if exists && currentStr == incoming {
+			continue
+		}
+
+		if !force {
+			if srcFlat != nil {
+				if src, ok := srcFlat[u.ID].(string); ok && src != u.Source {
+					result.Conflicts = append(result.Conflicts, XLIFFConflict{Key: u.ID, Reason: "source changed", Current: src, Incoming: u.Source})
+					continue
+				}
+			}
+			if exists && current != nil && currentStr != "" {
+				result.Conflicts = append(result.Conflicts, XLIFFConflict{Key: u.ID, Reason: "value changed", Current: currentStr, Incoming: incoming})
+				continue
+			}
+		}
+
+		if exists {
+			err = replaceNode(doc.root, u.ID, stringNode(incoming))
+		} else {
+			err = tm.addNestedKey(doc.root, u.ID, stringNode(incoming))
+		}
+		if err != nil {
+			result.Conflicts = append(result.Conflicts, XLIFFConflict{Key: u.ID, Reason: err.Error(), Incoming: incoming})
+			continue
+		}
+		result.Applied = append(result.Applied, u.ID)
+	}
+
+	if len(result.Applied) == 0 {
+		return result, nil
+	}
+
+	if err := tm.saveLanguage(lang, true); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
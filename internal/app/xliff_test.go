@@ -0,0 +1,80 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeLocale(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestXLIFF_ExportImport(t *testing.T) {
+	for _, version := range []string{XLIFF12, XLIFF20} {
+		t.Run(version, func(t *testing.T) {
+			dir := t.TempDir()
+			files := map[string]string{
+				"en": writeLocale(t, dir, "en.json", `{"a": {"save": "Save", "cancel": "Cancel"}, "hello": "Hello"}`),
+				"de": writeLocale(t, dir, "de.json", `{"a": {"save": "Speichern"}, "hello": "Hallo"}`),
+			}
+			tm, err := NewTranslationManager(files)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			written, err := tm.ExportXLIFF("en", version, dir)
+			if err != nil {
+				t.Fatalf("ExportXLIFF: %v", err)
+			}
+			if len(written) != 1 {
+				t.Fatalf("expected one XLIFF file, got %v", written)
+			}
+			content, _ := os.ReadFile(written[0])
+			if !strings.Contains(string(content), "Speichern") {
+				t.Fatalf("existing target missing from export:\n%s", content)
+			}
+
+			// simulate the agency: translate the missing unit and change an existing one
+			out := strings.Replace(string(content), "Hallo", "Servus", 1)
+			if version == XLIFF12 {
+				out = strings.Replace(out, `<target state="needs-translation"></target>`, `<target state="translated">Abbrechen</target>`, 1)
+			} else {
+				out = strings.Replace(out, `<source>Cancel</source>`, `<source>Cancel</source><target>Abbrechen</target>`, 1)
+			}
+			if err := os.WriteFile(written[0], []byte(out), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := tm.ImportXLIFF(written[0], false)
+			if err != nil {
+				t.Fatalf("ImportXLIFF: %v", err)
+			}
+			if len(res.Applied) != 1 || res.Applied[0] != "a.cancel" {
+				t.Fatalf("expected a.cancel to be applied, got %v", res.Applied)
+			}
+			if len(res.Conflicts) != 1 || res.Conflicts[0].Key != "hello" {
+				t.Fatalf("expected a conflict for hello, got %+v", res.Conflicts)
+			}
+
+			reloaded, err := NewTranslationManager(map[string]string{"de": files["de"]})
+			if err != nil {
+				t.Fatal(err)
+			}
+			flat := reloaded.flattenKeys("", reloaded.data["de"])
+			if flat["a.cancel"] != "Abbrechen" || flat["hello"] != "Hallo" {
+				t.Fatalf("unexpected merge result: %v", flat)
+			}
+			backups, _ := filepath.Glob(files["de"] + ".backup.*")
+			if len(backups) == 0 {
+				t.Fatalf("expected a backup of de.json")
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package messageformat
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mlechner911/i18ntool/internal/plural"
+)
+
+// Format renders the message with args. lang selects the CLDR rules for
+// plural and selectordinal arguments (English when unknown); numbers are
+// printed without grouping and dates with English patterns whatever lang is.
+// A missing argument, or a non-numeric plural value, is an error.
+func (m *Message) Format(lang string, args map[string]interface{}) (string, error) {
+	f := &formatter{lang: lang, args: args}
+	var b strings.Builder
+	if err := f.write(&b, m.parts, nil); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+type formatter struct {
+	lang string
+	args map[string]interface{}
+}
+
+// pluralValue is the number # stands for in a plural sub-message.
+type pluralValue struct {
+	value  interface{}
+	offset float64
+}
+
+func (f *formatter) write(b *strings.Builder, parts []part, pv *pluralValue) error {
+	for _, p := range parts {
+		switch {
+		case p.pound:
+			v := pv.value
+			if pv.offset != 0 {
+				n, _ := toFloat(v)
+				v = n - pv.offset
+			}
+			b.WriteString(formatNumber(v))
+		case p.arg != nil:
+			if err := f.argument(b, p.arg, pv); err != nil {
+				return err
+			}
+		default:
+			b.WriteString(p.text)
+		}
+	}
+	return nil
+}
+
+func (f *formatter) argument(b *strings.Builder, a *argument, pv *pluralValue) error {
+	v, ok := f.args[a.name]
+	if !ok {
+		return fmt.Errorf("missing argument %q", a.name)
+	}
+	switch a.typ {
+	case TypeNumber:
+		b.WriteString(formatNumberStyle(v, a.style))
+	case TypeDate, TypeTime:
+		b.WriteString(formatTime(v, a.typ, a.style))
+	case TypePlural, TypeSelectOrdinal:
+		n, err := toFloat(v)
+		if err != nil {
+			return fmt.Errorf("argument %q: %w", a.name, err)
+		}
+		rules, ok := plural.For(f.lang)
+		if a.typ == TypeSelectOrdinal {
+			rules, ok = plural.ForOrdinal(f.lang)
+		}
+		if !ok {
+			rules, _ = plural.For("en")
+			if a.typ == TypeSelectOrdinal {
+				rules, _ = plural.ForOrdinal("en")
+			}
+		}
+		// exact matches (=0) see the value itself, categories the value minus the offset
+		var category string
+		if a.offset == 0 {
+			category = rules.Select(v)
+		} else {
+			category = rules.Select(n - a.offset)
+		}
+		msg := a.option("=" + formatNumber(n))
+		if msg == nil {
+			msg = a.option(category)
+		}
+		if msg == nil {
+			msg = a.option("other")
+		}
+		return f.write(b, msg, &pluralValue{value: v, offset: a.offset})
+	case TypeSelect:
+		msg := a.option(fmt.Sprint(v))
+		if msg == nil {
+			msg = a.option("other")
+		}
+		return f.write(b, msg, pv)
+	default:
+		b.WriteString(fmt.Sprint(v))
+	}
+	return nil
+}
+
+// option returns the sub-message of selector, or nil.
+func (a *argument) option(selector string) []part {
+	for _, o := range a.options {
+		if o.selector == selector {
+			return o.message
+		}
+	}
+	return nil
+}
+
+// toFloat converts the numeric types and decimal strings to float64.
+func toFloat(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), nil
+	case int8:
+		return float64(n), nil
+	case int16:
+		return float64(n), nil
+	case int32:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case uint:
+		return float64(n), nil
+	case uint8:
+		return float64(n), nil
+	case uint16:
+		return float64(n), nil
+	case uint32:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	case float32:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case json.Number:
+		return n.Float64()
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(n), 64)
+	}
+	return 0, fmt.Errorf("%v (%T) is not a number", v, v)
+}
+
+// formatNumber prints v without exponent; decimal strings keep their digits.
+func formatNumber(v interface{}) string {
+	switch n := v.(type) {
+	case float32:
+		return strconv.FormatFloat(float64(n), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// formatNumberStyle applies the "integer" and "percent" number styles; other
+// styles and skeletons print the plain number.
+func formatNumberStyle(v interface{}, style string) string {
+	n, err := toFloat(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	switch style {
+	case "integer":
+		return strconv.FormatFloat(math.Round(n), 'f', -1, 64)
+	case "percent":
+		return strconv.FormatFloat(n*100, 'f', -1, 64) + "%"
+	}
+	return formatNumber(v)
+}
+
+// datePatterns and timePatterns map ICU styles to English Go layouts.
+var (
+	datePatterns = map[string]string{
+		"short":  "1/2/06",
+		"":       "Jan 2, 2006",
+		"medium": "Jan 2, 2006",
+		"long":   "January 2, 2006",
+		"full":   "Monday, January 2, 2006",
+	}
+	timePatterns = map[string]string{
+		"short":  "3:04 PM",
+		"":       "3:04:05 PM",
+		"medium": "3:04:05 PM",
+		"long":   "3:04:05 PM MST",
+		"full":   "3:04:05 PM MST",
+	}
+)
+
+// formatTime formats a time.Time; unknown styles and skeletons use medium.
+func formatTime(v interface{}, typ, style string) string {
+	t, ok := v.(time.Time)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	patterns := datePatterns
+	if typ == TypeTime {
+		patterns = timePatterns
+	}
+	layout, ok := patterns[style]
+	if !ok {
+		layout = patterns["medium"]
+	}
+	return t.Format(layout)
+}
@@ -0,0 +1,395 @@
+// Package messageformat parses and formats ICU MessageFormat strings such as
+// "{count, plural, one {# item} other {# items}}", the syntax used by
+// formatjs, vue-i18n and i18next-icu. Plural and selectordinal arguments use
+// the CLDR rules of package plural.
+package messageformat
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mlechner911/i18ntool/internal/plural"
+)
+
+// Argument types. Simple arguments ({name}) have type "".
+const (
+	TypeNone          = ""
+	TypeNumber        = "number"
+	TypeDate          = "date"
+	TypeTime          = "time"
+	TypePlural        = "plural"
+	TypeSelectOrdinal = "selectordinal"
+	TypeSelect        = "select"
+)
+
+// simpleTypes are the argument types that take an optional style but no
+// sub-messages; only number, date and time change the output.
+var simpleTypes = map[string]bool{
+	TypeNumber: true, TypeDate: true, TypeTime: true,
+	"spellout": true, "ordinal": true, "duration": true,
+}
+
+// SyntaxError is a parse error at a byte offset of the message.
+type SyntaxError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("offset %d: %s", e.Offset, e.Msg)
+}
+
+// part is a piece of a message: literal text, an argument or the # of a
+// plural sub-message.
+type part struct {
+	text  string
+	arg   *argument
+	pound bool
+}
+
+type argument struct {
+	name    string
+	typ     string
+	style   string
+	offset  float64
+	options []option
+}
+
+// option is one "selector {message}" arm of a plural or select argument.
+type option struct {
+	selector string
+	message  []part
+}
+
+// Message is a parsed MessageFormat pattern. It is immutable and safe for
+// concurrent use.
+type Message struct {
+	parts []part
+}
+
+// Parse parses a MessageFormat pattern. Apostrophes quote syntax characters
+// as in ICU: '{' is a literal brace and a doubled apostrophe a literal one.
+func Parse(s string) (*Message, error) {
+	p := &parser{src: s}
+	parts, err := p.message(false, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{parts: parts}, nil
+}
+
+var complexArgRe = regexp.MustCompile(`\{\s*[\p{L}\p{N}_.]+\s*,\s*(plural|selectordinal|select|number|date|time|spellout|ordinal|duration)\s*[,}]`)
+
+// LooksLikeICU reports whether s contains a typed MessageFormat argument such
+// as {n, plural, ...} or {d, date}. Plain {name} tokens are common outside
+// MessageFormat, so they alone do not count.
+func LooksLikeICU(s string) bool {
+	return complexArgRe.MatchString(s)
+}
+
+// Arguments returns the arguments the message uses, including those nested
+// in sub-messages, mapped to their type. A name used both plainly and typed
+// maps to the type.
+func (m *Message) Arguments() map[string]string {
+	args := make(map[string]string)
+	var walk func(parts []part)
+	walk = func(parts []part) {
+		for _, p := range parts {
+			if p.arg == nil {
+				continue
+			}
+			if t, ok := args[p.arg.name]; !ok || t == TypeNone {
+				args[p.arg.name] = p.arg.typ
+			}
+			for _, o := range p.arg.options {
+				walk(o.message)
+			}
+		}
+	}
+	walk(m.parts)
+	return args
+}
+
+// ArgumentList renders Arguments as sorted "name" or "name:type" strings.
+func (m *Message) ArgumentList() []string {
+	var out []string
+	for name, typ := range m.Arguments() {
+		if typ != TypeNone {
+			name += ":" + typ
+		}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) errorf(format string, a ...interface{}) error {
+	return &SyntaxError{Offset: p.pos, Msg: fmt.Sprintf(format, a...)}
+}
+
+func (p *parser) eof() bool { return p.pos >= len(p.src) }
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		p.pos += size
+	}
+}
+
+// message parses text and arguments up to the end of input or, for nested
+// sub-messages, up to the closing brace, which is left for the caller.
+func (p *parser) message(nested, inPlural bool) ([]part, error) {
+	var parts []part
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			parts = append(parts, part{text: text.String()})
+			text.Reset()
+		}
+	}
+	for !p.eof() {
+		c := p.peek()
+		switch {
+		case c == '}':
+			if !nested {
+				return nil, p.errorf("unexpected '}'")
+			}
+			flush()
+			return parts, nil
+		case c == '{':
+			flush()
+			arg, err := p.argument(inPlural)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, part{arg: arg})
+		case c == '#' && inPlural:
+			flush()
+			parts = append(parts, part{pound: true})
+			p.pos++
+		case c == '\'':
+			p.quoted(&text, inPlural)
+		default:
+			text.WriteByte(c)
+			p.pos++
+		}
+	}
+	if nested {
+		return nil, p.errorf("unterminated sub-message, expected '}'")
+	}
+	flush()
+	return parts, nil
+}
+
+// quoted handles an apostrophe: a doubled one is literal, and before a
+// syntax character it starts quoted text up to the next single apostrophe.
+// Anywhere else it is literal.
+func (p *parser) quoted(text *strings.Builder, inPlural bool) {
+	p.pos++
+	next := p.peek()
+	switch {
+	case next == '\'':
+		text.WriteByte('\'')
+		p.pos++
+		return
+	case next == '{' || next == '}' || (next == '#' && inPlural) || next == '|':
+	default:
+		text.WriteByte('\'')
+		return
+	}
+	for !p.eof() {
+		c := p.src[p.pos]
+		p.pos++
+		if c != '\'' {
+			text.WriteByte(c)
+			continue
+		}
+		if p.peek() == '\'' {
+			text.WriteByte('\'')
+			p.pos++
+			continue
+		}
+		return
+	}
+}
+
+// identifier reads an argument name, type keyword or selector.
+func (p *parser) identifier() string {
+	start := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' && r != '-' {
+			break
+		}
+		p.pos += size
+	}
+	return p.src[start:p.pos]
+}
+
+// argument parses "{name}", "{name, type[, style]}" or a plural/select
+// argument starting at the opening brace.
+func (p *parser) argument(inPlural bool) (*argument, error) {
+	p.pos++ // {
+	p.skipSpace()
+	arg := &argument{name: p.identifier()}
+	if arg.name == "" {
+		return nil, p.errorf("expected argument name")
+	}
+	p.skipSpace()
+	switch p.peek() {
+	case '}':
+		p.pos++
+		return arg, nil
+	case ',':
+		p.pos++
+	default:
+		return nil, p.errorf("expected ',' or '}' after argument %q", arg.name)
+	}
+
+	p.skipSpace()
+	typeStart := p.pos
+	arg.typ = p.identifier()
+	p.skipSpace()
+	switch {
+	case arg.typ == TypePlural || arg.typ == TypeSelectOrdinal || arg.typ == TypeSelect:
+		if p.peek() != ',' {
+			return nil, p.errorf("expected ',' after %s", arg.typ)
+		}
+		p.pos++
+		if err := p.options(arg, inPlural); err != nil {
+			return nil, err
+		}
+		return arg, nil
+	case simpleTypes[arg.typ]:
+		if p.peek() == ',' {
+			p.pos++
+			style, err := p.style()
+			if err != nil {
+				return nil, err
+			}
+			arg.style = style
+		}
+		if p.peek() != '}' {
+			return nil, p.errorf("expected '}' after argument %q", arg.name)
+		}
+		p.pos++
+		return arg, nil
+	}
+	p.pos = typeStart
+	return nil, p.errorf("unknown argument type %q", arg.typ)
+}
+
+// style reads the raw style text up to the closing brace of the argument.
+func (p *parser) style() (string, error) {
+	start := p.pos
+	depth := 0
+	for !p.eof() {
+		switch p.peek() {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return strings.TrimSpace(p.src[start:p.pos]), nil
+			}
+			depth--
+		}
+		p.pos++
+	}
+	return "", p.errorf("unterminated argument style")
+}
+
+// options parses the "selector {message}" arms of a plural or select argument
+// and the closing brace. An "other" arm is required. # stays bound to the
+// nearest enclosing plural, so select arms inherit inPlural.
+func (p *parser) options(arg *argument, inPlural bool) error {
+	seen := make(map[string]bool)
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return p.errorf("unterminated %s argument %q", arg.typ, arg.name)
+		}
+		if p.peek() == '}' {
+			p.pos++
+			break
+		}
+		selStart := p.pos
+		var selector string
+		if p.peek() == '=' {
+			p.pos++
+			selector = "=" + p.identifier()
+		} else {
+			selector = p.identifier()
+		}
+		if arg.typ != TypeSelect && selector == "offset" && p.peek() == ':' {
+			if err := p.offset(arg, len(arg.options) > 0); err != nil {
+				return err
+			}
+			continue
+		}
+		if selector == "" || selector == "=" {
+			return p.errorf("expected selector in %s argument %q", arg.typ, arg.name)
+		}
+		if arg.typ != TypeSelect && !strings.HasPrefix(selector, "=") && !plural.IsCategory(selector) {
+			p.pos = selStart
+			return p.errorf("invalid %s selector %q", arg.typ, selector)
+		}
+		if seen[selector] {
+			p.pos = selStart
+			return p.errorf("duplicate selector %q", selector)
+		}
+		seen[selector] = true
+		p.skipSpace()
+		if p.peek() != '{' {
+			return p.errorf("expected '{' after selector %q", selector)
+		}
+		p.pos++
+		msg, err := p.message(true, arg.typ != TypeSelect || inPlural)
+		if err != nil {
+			return err
+		}
+		p.pos++ // }
+		arg.options = append(arg.options, option{selector: selector, message: msg})
+	}
+	if !seen["other"] {
+		return &SyntaxError{Offset: p.pos - 1, Msg: fmt.Sprintf("%s argument %q has no 'other' option", arg.typ, arg.name)}
+	}
+	return nil
+}
+
+// offset parses "offset:N", which must come before the first selector.
+func (p *parser) offset(arg *argument, late bool) error {
+	if late {
+		return p.errorf("offset must precede the selectors")
+	}
+	p.pos++ // :
+	p.skipSpace()
+	start := p.pos
+	for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return p.errorf("expected number after offset:")
+	}
+	arg.offset, _ = strconv.ParseFloat(p.src[start:p.pos], 64)
+	return nil
+}
@@ -0,0 +1,101 @@
+package messageformat
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		lang, pattern string
+		args          map[string]interface{}
+		want          string
+	}{
+		{"en", "Hello {name}!", map[string]interface{}{"name": "Ann"}, "Hello Ann!"},
+		{"en", "{count, plural, one {# item} other {# items}}", map[string]interface{}{"count": 1}, "1 item"},
+		{"en", "{count, plural, one {# item} other {# items}}", map[string]interface{}{"count": 5}, "5 items"},
+		{"en", "{count, plural, =0 {no items} one {# item} other {# items}}", map[string]interface{}{"count": 0}, "no items"},
+		{"pl", "{n, plural, one {# plik} few {# pliki} many {# plików} other {# pliku}}", map[string]interface{}{"n": 22}, "22 pliki"},
+		{"pl", "{n, plural, one {# plik} few {# pliki} many {# plików} other {# pliku}}", map[string]interface{}{"n": 25}, "25 plików"},
+		{"en", "{n, plural, offset:1 =0 {nobody} =1 {{name}} one {{name} and # other} other {{name} and # others}}", map[string]interface{}{"n": 3, "name": "Ann"}, "Ann and 2 others"},
+		{"en", "{n, plural, offset:1 =0 {nobody} =1 {{name}} one {{name} and # other} other {{name} and # others}}", map[string]interface{}{"n": 2, "name": "Ann"}, "Ann and 1 other"},
+		{"en", "{pos, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}", map[string]interface{}{"pos": 22}, "22nd"},
+		{"en", "{pos, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}", map[string]interface{}{"pos": 13}, "13th"},
+		{"en", "{g, select, female {She} male {He} other {They}} left", map[string]interface{}{"g": "female"}, "She left"},
+		{"en", "{g, select, female {She} other {They}} left", map[string]interface{}{"g": "x"}, "They left"},
+		{"en", "{n, plural, other {{g, select, a {# a} other {# b}}}}", map[string]interface{}{"n": 4, "g": "a"}, "4 a"},
+		{"en", "{r, number, percent} done, {c, number, integer} left", map[string]interface{}{"r": 0.25, "c": 2.6}, "25% done, 3 left"},
+		{"en", "It''s '{literal}' and # here", nil, "It's {literal} and # here"},
+		{"en", "{d, date, long}", map[string]interface{}{"d": time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)}, "March 4, 2025"},
+		{"xx", "{count, plural, one {# item} other {# items}}", map[string]interface{}{"count": "1"}, "1 item"},
+	}
+	for _, tt := range tests {
+		m, err := Parse(tt.pattern)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.pattern, err)
+		}
+		got, err := m.Format(tt.lang, tt.args)
+		if err != nil || got != tt.want {
+			t.Errorf("%q %v: want %q, got %q (%v)", tt.pattern, tt.args, tt.want, got, err)
+		}
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	m, _ := Parse("{count, plural, one {# item} other {# items}}")
+	if _, err := m.Format("en", nil); err == nil {
+		t.Error("expected missing argument error")
+	}
+	if _, err := m.Format("en", map[string]interface{}{"count": "many"}); err == nil {
+		t.Error("expected error for non-numeric plural value")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]int{
+		"{count, plural, one {# item}}":                   28,
+		"{count, plural, one {# item} other {# items}":    44,
+		"{count, plural, one {# item} one {x} other {y}}": 29,
+		"{count, plural, single {x} other {y}}":           16,
+		"{count, plurl, one {x} other {y}}":               8,
+		"Hello {}":                                        7,
+		"a } b":                                           2,
+	}
+	for pattern, offset := range tests {
+		_, err := Parse(pattern)
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("%q: expected syntax error, got %v", pattern, err)
+			continue
+		}
+		if se.Offset != offset {
+			t.Errorf("%q: want offset %d, got %d (%v)", pattern, offset, se.Offset, se)
+		}
+	}
+}
+
+func TestArguments(t *testing.T) {
+	m, err := Parse("{name} has {n, plural, one {# {kind}} other {# {kind}s}} since {d, date, short}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"d:date", "kind", "n:plural", "name"}
+	if got := m.ArgumentList(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestLooksLikeICU(t *testing.T) {
+	for s, want := range map[string]bool{
+		"{count, plural, one {#} other {#}}": true,
+		"{d, date}":                          true,
+		"Hello {name}":                       false,
+		"{{.Count}} items":                   false,
+	} {
+		if got := LooksLikeICU(s); got != want {
+			t.Errorf("%q: want %v, got %v", s, want, got)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+// Package plural selects CLDR plural categories. The cardinal and ordinal
+// rules are embedded from CLDR's supplemental plurals.json and ordinals.json
+// (samples omitted), so no data is needed at runtime. Only a subset of CLDR
+// is embedded: 59 common languages for cardinals and 54 for ordinals. For
+// and ForOrdinal report false for the others.
+package plural
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed cldr_plurals.json
+var cldrPlurals []byte
+
+//go:embed cldr_ordinals.json
+var cldrOrdinals []byte
+
+// Order lists the CLDR plural categories in their canonical order.
+var Order = []string{"zero", "one", "two", "few", "many", "other"}
+
+// IsCategory reports whether s names a CLDR plural category.
+func IsCategory(s string) bool {
+	for _, c := range Order {
+		if c == s {
+			return true
+		}
+	}
+	return false
+}
+
+// relation is one comparison such as "n % 10 = 2..4". A plain value is
+// stored as a range with equal bounds.
+type relation struct {
+	operand byte
+	mod     float64
+	negate  bool
+	ranges  [][2]float64
+}
+
+// condition is a rule in disjunctive form: any of the and-groups must hold.
+type condition [][]relation
+
+// Rules are the cardinal or ordinal plural rules of one language.
+type Rules struct {
+	Language   string
+	categories []string
+	conditions map[string]condition
+}
+
+var (
+	loadOnce sync.Once
+	rules    map[string]*Rules // cardinal
+	ordinals map[string]*Rules
+)
+
+// load parses the embedded CLDR data once. The data ships with the binary,
+// so a parse error is a programming error and panics.
+func load() {
+	rules = parseRules(cldrPlurals, "plurals-type-cardinal")
+	ordinals = parseRules(cldrOrdinals, "plurals-type-ordinal")
+}
+
+// parseRules reads one rule set of a CLDR supplemental plurals file.
+func parseRules(data []byte, typ string) map[string]*Rules {
+	var doc struct {
+		Supplemental map[string]json.RawMessage `json:"supplemental"`
+	}
+	var langs map[string]map[string]string
+	if err := json.Unmarshal(data, &doc); err != nil {
+		panic(fmt.Sprintf("plural: embedded CLDR data: %v", err))
+	}
+	if err := json.Unmarshal(doc.Supplemental[typ], &langs); err != nil || len(langs) == 0 {
+		panic(fmt.Sprintf("plural: embedded CLDR data has no %s rules", typ))
+	}
+	out := make(map[string]*Rules, len(langs))
+	for lang, raw := range langs {
+		r := &Rules{Language: lang, conditions: make(map[string]condition)}
+		for _, cat := range Order {
+			src, ok := raw["pluralRule-count-"+cat]
+			if !ok {
+				continue
+			}
+			r.categories = append(r.categories, cat)
+			c, err := parseCondition(src)
+			if err != nil {
+				panic(fmt.Sprintf("plural: %s %s %s: %v", typ, lang, cat, err))
+			}
+			r.conditions[cat] = c
+		}
+		out[lang] = r
+	}
+	return out
+}
+
+// For returns the cardinal rules of a language tag such as "pl", "pt-PT" or
+// "de_AT", falling back from the full tag to its base language.
+func For(lang string) (*Rules, bool) {
+	loadOnce.Do(load)
+	return lookup(rules, lang)
+}
+
+// ForOrdinal returns the ordinal rules (1st, 2nd, 3rd) of a language tag.
+func ForOrdinal(lang string) (*Rules, bool) {
+	loadOnce.Do(load)
+	return lookup(ordinals, lang)
+}
+
+func lookup(set map[string]*Rules, lang string) (*Rules, bool) {
+	tag := strings.ReplaceAll(lang, "_", "-")
+	base, region, _ := strings.Cut(tag, "-")
+	base = strings.ToLower(base)
+	if region != "" {
+		if r, ok := set[base+"-"+strings.ToUpper(region)]; ok {
+			return r, true
+		}
+	}
+	r, ok := set[base]
+	return r, ok
+}
+
+// Categories returns the categories lang uses in canonical order, or nil
+// when there are no rules for it.
+func Categories(lang string) []string {
+	r, ok := For(lang)
+	if !ok {
+		return nil
+	}
+	return r.Categories()
+}
+
+// Categories returns the categories of the language in canonical order.
+func (r *Rules) Categories() []string {
+	return append([]string(nil), r.categories...)
+}
+
+// Select returns the category for n, which may be any integer or float type,
+// json.Number or a decimal string. Strings keep visible fraction digits, so
+// "1.0" selects differently from 1 in languages like English. Values that are
+// not numbers select "other".
+func (r *Rules) Select(n interface{}) string {
+	ops, err := NewOperands(n)
+	if err != nil {
+		return "other"
+	}
+	for _, cat := range r.categories {
+		if cat != "other" && r.conditions[cat].match(ops) {
+			return cat
+		}
+	}
+	return "other"
+}
+
+// Operands are the CLDR plural operands of a number: the absolute value n,
+// its integer digits i, the number of visible fraction digits with (v) and
+// without (w) trailing zeros, the fraction digits as integer with (f) and
+// without (t) trailing zeros, and the compact exponent e.
+type Operands struct {
+	N             float64
+	I, V, W, F, T int64
+	E             int64
+}
+
+// NewOperands computes the operands of n; see Rules.Select for the accepted
+// types.
+func NewOperands(n interface{}) (Operands, error) {
+	switch v := n.(type) {
+	case int:
+		return intOperands(int64(v)), nil
+	case int8:
+		return intOperands(int64(v)), nil
+	case int16:
+		return intOperands(int64(v)), nil
+	case int32:
+		return intOperands(int64(v)), nil
+	case int64:
+		return intOperands(v), nil
+	case uint:
+		return decimalOperands(strconv.FormatUint(uint64(v), 10))
+	case uint8:
+		return intOperands(int64(v)), nil
+	case uint16:
+		return intOperands(int64(v)), nil
+	case uint32:
+		return intOperands(int64(v)), nil
+	case uint64:
+		return decimalOperands(strconv.FormatUint(v, 10))
+	case float32:
+		return decimalOperands(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		return decimalOperands(strconv.FormatFloat(v, 'f', -1, 64))
+	case json.Number:
+		return decimalOperands(string(v))
+	case string:
+		return decimalOperands(v)
+	}
+	return Operands{}, fmt.Errorf("plural: unsupported count type %T", n)
+}
+
+func intOperands(v int64) Operands {
+	if v < 0 {
+		v = -v
+	}
+	return Operands{N: float64(v), I: v}
+}
+
+// decimalOperands parses a plain decimal such as "-12.50".
+func decimalOperands(s string) (Operands, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "-")
+	intPart, frac, _ := strings.Cut(s, ".")
+	var ops Operands
+	var err error
+	if ops.I, err = strconv.ParseInt(intPart, 10, 64); err != nil {
+		return Operands{}, fmt.Errorf("plural: invalid number %q", s)
+	}
+	if ops.N, err = strconv.ParseFloat(s, 64); err != nil {
+		return Operands{}, fmt.Errorf("plural: invalid number %q", s)
+	}
+	if frac != "" {
+		if ops.F, err = strconv.ParseInt(frac, 10, 64); err != nil {
+			return Operands{}, fmt.Errorf("plural: invalid number %q", s)
+		}
+		ops.V = int64(len(frac))
+		trimmed := strings.TrimRight(frac, "0")
+		ops.W = int64(len(trimmed))
+		if trimmed != "" {
+			ops.T, _ = strconv.ParseInt(trimmed, 10, 64)
+		}
+	}
+	return ops, nil
+}
+
+// value returns the named operand.
+func (o Operands) value(operand byte) float64 {
+	switch operand {
+	case 'n':
+		return o.N
+	case 'i':
+		return float64(o.I)
+	case 'v':
+		return float64(o.V)
+	case 'w':
+		return float64(o.W)
+	case 'f':
+		return float64(o.F)
+	case 't':
+		return float64(o.T)
+	}
+	return float64(o.E) // e and its deprecated alias c
+}
+
+func (c condition) match(ops Operands) bool {
+	for _, and := range c {
+		ok := true
+		for _, rel := range and {
+			if !rel.match(ops) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// match tests the relation. Ranges only contain integers, so a value with a
+// fraction such as n = 1.5 is in no range.
+func (r relation) match(ops Operands) bool {
+	x := ops.value(r.operand)
+	if r.mod > 0 {
+		x = math.Mod(x, r.mod)
+	}
+	in := false
+	for _, rg := range r.ranges {
+		if x == math.Trunc(x) && x >= rg[0] && x <= rg[1] {
+			in = true
+			break
+		}
+	}
+	return in != r.negate
+}
+
+// parseCondition parses the condition part of a CLDR rule, e.g.
+// "v = 0 and i % 10 = 2..4 or f != 0". An empty rule never matches.
+func parseCondition(s string) (condition, error) {
+	if i := strings.Index(s, "@"); i >= 0 {
+		s = s[:i] // drop samples
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	var c condition
+	for _, or := range strings.Split(s, " or ") {
+		var and []relation
+		for _, rel := range strings.Split(or, " and ") {
+			r, err := parseRelation(rel)
+			if err != nil {
+				return nil, err
+			}
+			and = append(and, r)
+		}
+		c = append(c, and)
+	}
+	return c, nil
+}
+
+// parseRelation parses "operand [% mod] (= | != | is [not] | [not] in) ranges".
+// The deprecated "within" is not used by current CLDR data.
+func parseRelation(s string) (relation, error) {
+	f := strings.Fields(s)
+	if len(f) < 3 || len(f[0]) != 1 || !strings.Contains("nivwftec", f[0]) {
+		return relation{}, fmt.Errorf("invalid relation %q", s)
+	}
+	r := relation{operand: f[0][0]}
+	f = f[1:]
+	if f[0] == "%" {
+		mod, err := strconv.ParseFloat(f[1], 64)
+		if err != nil || mod <= 0 {
+			return relation{}, fmt.Errorf("invalid modulus in %q", s)
+		}
+		r.mod = mod
+		f = f[2:]
+	}
+	switch {
+	case len(f) == 2 && (f[0] == "=" || f[0] == "is" || f[0] == "in"):
+	case len(f) == 2 && f[0] == "!=":
+		r.negate = true
+	case len(f) == 3 && ((f[0] == "is" && f[1] == "not") || (f[0] == "not" && f[1] == "in")):
+		r.negate = true
+		f = f[1:]
+	default:
+		return relation{}, fmt.Errorf("invalid relation %q", s)
+	}
+	for _, part := range strings.Split(f[1], ",") {
+		lo, hi, isRange := strings.Cut(part, "..")
+		if !isRange {
+			hi = lo
+		}
+		l, err1 := strconv.ParseFloat(lo, 64)
+		h, err2 := strconv.ParseFloat(hi, 64)
+		if err1 != nil || err2 != nil {
+			return relation{}, fmt.Errorf("invalid range %q in %q", part, s)
+		}
+		r.ranges = append(r.ranges, [2]float64{l, h})
+	}
+	return r, nil
+}
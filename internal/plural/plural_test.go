@@ -0,0 +1,95 @@
+package plural
+
+import "testing"
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    interface{}
+		want string
+	}{
+		{"en", 1, "one"},
+		{"en", 0, "other"},
+		{"en", "1.0", "other"},
+		{"en", 1.5, "other"},
+		{"de-AT", 1, "one"},
+		{"fr", 0, "one"},
+		{"fr", 1.5, "one"},
+		{"fr", 2, "other"},
+		{"fr", 1000000, "many"},
+		{"pl", 1, "one"},
+		{"pl", 3, "few"},
+		{"pl", 22, "few"},
+		{"pl", 12, "many"},
+		{"pl", 5, "many"},
+		{"pl", 21, "many"},
+		{"pl", "1.5", "other"},
+		{"ru", 21, "one"},
+		{"ru", 11, "many"},
+		{"ar", 0, "zero"},
+		{"ar", 2, "two"},
+		{"ar", 105, "few"},
+		{"ar", 111, "many"},
+		{"ar", 100, "other"},
+		{"cs", "2.5", "many"},
+		{"lv", 10, "zero"},
+		{"pt", 0, "one"},
+		{"pt_PT", 0, "other"},
+		{"ja", 1, "other"},
+		{"en", -1, "one"},
+		{"en", "x", "other"},
+	}
+	for _, tt := range tests {
+		r, ok := For(tt.lang)
+		if !ok {
+			t.Fatalf("no rules for %s", tt.lang)
+		}
+		if got := r.Select(tt.n); got != tt.want {
+			t.Errorf("%s %v: want %s, got %s", tt.lang, tt.n, tt.want, got)
+		}
+	}
+}
+
+func TestSelectOrdinal(t *testing.T) {
+	en, _ := ForOrdinal("en-GB")
+	for n, want := range map[int]string{1: "one", 2: "two", 3: "few", 4: "other", 11: "other", 12: "other", 21: "one", 102: "two"} {
+		if got := en.Select(n); got != want {
+			t.Errorf("en %d: want %s, got %s", n, want, got)
+		}
+	}
+	if de, _ := ForOrdinal("de"); de.Select(1) != "other" {
+		t.Error("de ordinals only use other")
+	}
+}
+
+func TestCategories(t *testing.T) {
+	if got := Categories("pl"); len(got) != 4 || got[0] != "one" || got[3] != "other" {
+		t.Fatalf("unexpected pl categories %v", got)
+	}
+	if got := Categories("xx"); got != nil {
+		t.Fatalf("expected no categories for unknown language, got %v", got)
+	}
+	// every embedded rule set must parse and end in other
+	loadOnce.Do(load)
+	for _, set := range []map[string]*Rules{rules, ordinals} {
+		for lang, r := range set {
+			if cats := r.Categories(); len(cats) == 0 || cats[len(cats)-1] != "other" {
+				t.Errorf("%s: categories %v", lang, cats)
+			}
+		}
+	}
+}
+
+func TestNewOperands(t *testing.T) {
+	ops, err := NewOperands("1.230")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Operands{N: 1.23, I: 1, V: 3, W: 2, F: 230, T: 23}
+	if ops != want {
+		t.Fatalf("want %+v, got %+v", want, ops)
+	}
+	if _, err := NewOperands(struct{}{}); err == nil {
+		t.Fatal("expected error for unsupported type")
+	}
+}
@@ -0,0 +1,211 @@
+package simpletrans
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Bundle holds the translations of several locales and resolves each lookup
+// through a fallback chain such as de-AT → de → en, so regional files only
+// need the keys that differ. Each locale is compiled into a Catalog when it
+// is added. It is safe for concurrent use.
+type Bundle struct {
+	mu            sync.RWMutex
+	defaultLocale string
+	locales       map[string]*Catalog
+	parents       map[string][]string
+}
+
+// NewBundle returns an empty bundle whose chains all end in defaultLocale;
+// pass "" for no default.
+func NewBundle(defaultLocale string) *Bundle {
+	return &Bundle{
+		defaultLocale: CanonicalTag(defaultLocale),
+		locales:       make(map[string]*Catalog),
+		parents:       make(map[string][]string),
+	}
+}
+
+// CanonicalTag normalises a BCP 47 tag: "de_at" becomes "de-AT" and
+// "zh-hant-tw" becomes "zh-Hant-TW".
+func CanonicalTag(tag string) string {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	for i, p := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(p)
+		case len(p) == 2:
+			parts[i] = strings.ToUpper(p)
+		case len(p) == 4:
+			parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:])
+		default:
+			parts[i] = strings.ToLower(p)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+var languageNameRe = regexp.MustCompile(`^[A-Za-z]{2}([_-][A-Za-z]{2})?$`)
+
+// LanguageFromPath returns the language of a locale file the way the
+// i18n-manager CLI detects it: the file name without extension when it looks
+// like a language code ("de.json", "pt_BR.json"), else the parent directory
+// ("de/common.json"), else "".
+func LanguageFromPath(p string) string {
+	p = filepath.ToSlash(p)
+	name := strings.TrimSuffix(path.Base(p), path.Ext(p))
+	if languageNameRe.MatchString(name) {
+		return name
+	}
+	if parent := path.Base(path.Dir(p)); languageNameRe.MatchString(parent) {
+		return parent
+	}
+	return ""
+}
+
+// Add registers the translations of a locale, replacing earlier ones. Plural
+// and MessageFormat rules follow tag.
+func (b *Bundle) Add(tag string, t Translations) {
+	tag = CanonicalTag(tag)
+	c := Compile(t, tag)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.locales[tag] = c
+}
+
+// LoadFile loads a translation file with LoadTranslationsStrict and adds it
+// under tag, or under the language derived from the file name when tag is
+// empty. It returns the tag used.
+func (b *Bundle) LoadFile(tag, filename string) (string, error) {
+	t, err := LoadTranslationsStrict(filename)
+	if err != nil {
+		return "", err
+	}
+	if tag == "" {
+		tag = LanguageFromPath(filename)
+	}
+	if tag == "" {
+		return "", fmt.Errorf("cannot derive a locale from %s", filename)
+	}
+	tag = CanonicalTag(tag)
+	b.Add(tag, t)
+	return tag, nil
+}
+
+// SetFallback sets the locales tried after tag, replacing the default parent
+// obtained by dropping its last subtag. Parents resolve their own chains, so
+// SetFallback("pt-BR", "pt-PT") yields pt-BR → pt-PT → pt → default.
+func (b *Bundle) SetFallback(tag string, parents ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	canonical := make([]string, len(parents))
+	for i, p := range parents {
+		canonical[i] = CanonicalTag(p)
+	}
+	b.parents[CanonicalTag(tag)] = canonical
+}
+
+// Locales returns the tags of the loaded locales in sorted order.
+func (b *Bundle) Locales() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	tags := make([]string, 0, len(b.locales))
+	for tag := range b.locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Chain returns the loaded locales a lookup for tag tries, in order.
+func (b *Bundle) Chain(tag string) []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.chain(tag)
+}
+
+func (b *Bundle) chain(tag string) []string {
+	var chain []string
+	seen := make(map[string]bool)
+	var visit func(tag string)
+	visit = func(tag string) {
+		if tag == "" || seen[tag] {
+			return
+		}
+		seen[tag] = true
+		if _, ok := b.locales[tag]; ok {
+			chain = append(chain, tag)
+		}
+		parents, ok := b.parents[tag]
+		if !ok {
+			if i := strings.LastIndex(tag, "-"); i > 0 {
+				parents = []string{tag[:i]}
+			}
+		}
+		for _, p := range parents {
+			visit(p)
+		}
+	}
+	visit(CanonicalTag(tag))
+	visit(b.defaultLocale)
+	return chain
+}
+
+// Lookup returns the value of key for tag, rendered with data, together with
+// the locale of the chain that served it. ok is false when no locale in the
+// chain has the key.
+func (b *Bundle) Lookup(tag, key string, data map[string]interface{}) (text, locale string, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, loc := range b.chain(tag) {
+		if text, ok := b.locales[loc].Lookup(key, data); ok {
+			return text, loc, true
+		}
+	}
+	return "", "", false
+}
+
+// GetTranslation is Lookup returning fallback when no locale has the key.
+func (b *Bundle) GetTranslation(tag, key string, data map[string]interface{}, fallback string) string {
+	if text, _, ok := b.Lookup(tag, key, data); ok {
+		return text
+	}
+	return fallback
+}
+
+// GetPlural is GetPlural over the chain, using the plural rules of each
+// locale's tag. The whole chain is searched for the form of n's category
+// before any locale falls back to key_other, and then to key itself, so
+// de-AT with only item_other still gets item_one from de.
+func (b *Bundle) GetPlural(tag, key string, n interface{}, data map[string]interface{}) (text, locale string, err error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	chain := b.chain(tag)
+	for i := 0; i < 3; i++ {
+		for _, loc := range chain {
+			c := b.locales[loc]
+			if e, ok := c.entry(c.pluralKeys(key, n)[i]); ok {
+				return e.render(pluralData(n, data)), loc, nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("no plural form of %q for %s", key, tag)
+}
+
+// FormatMessage is FormatMessage over the chain.
+func (b *Bundle) FormatMessage(tag, key string, args map[string]interface{}) (text, locale string, err error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, loc := range b.chain(tag) {
+		if c := b.locales[loc]; c.Has(key) {
+			text, err := c.FormatMessage(key, args)
+			return text, loc, err
+		}
+	}
+	return "", "", fmt.Errorf("translation %q not found for %s", key, tag)
+}
@@ -0,0 +1,105 @@
+package simpletrans
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBundleFallbackChain(t *testing.T) {
+	b := NewBundle("en")
+	b.Add("en", Translations{"greeting": "Hello", "bye": "Bye", "cart": map[string]interface{}{"items_one": "{{.Count}} item", "items_other": "{{.Count}} items"}})
+	b.Add("de", Translations{"greeting": "Hallo", "cart": map[string]interface{}{"items_one": "{{.Count}} Artikel", "items_other": "{{.Count}} Artikel"}})
+	b.Add("de_at", Translations{"greeting": "Servus"})
+
+	if got := b.Chain("de-AT"); !reflect.DeepEqual(got, []string{"de-AT", "de", "en"}) {
+		t.Fatalf("unexpected chain %v", got)
+	}
+	if got := b.Chain("de-CH"); !reflect.DeepEqual(got, []string{"de", "en"}) {
+		t.Fatalf("unexpected chain for unloaded region %v", got)
+	}
+
+	tests := []struct{ tag, key, text, locale string }{
+		{"de-AT", "greeting", "Servus", "de-AT"},
+		{"de-AT", "bye", "Bye", "en"},
+		{"de-CH", "greeting", "Hallo", "de"},
+		{"fr", "greeting", "Hello", "en"},
+	}
+	for _, tt := range tests {
+		text, locale, ok := b.Lookup(tt.tag, tt.key, nil)
+		if !ok || text != tt.text || locale != tt.locale {
+			t.Errorf("%s %s: want %q from %s, got %q from %s", tt.tag, tt.key, tt.text, tt.locale, text, locale)
+		}
+	}
+	if _, _, ok := b.Lookup("de", "missing", nil); ok {
+		t.Error("expected missing key")
+	}
+	if got := b.GetTranslation("de", "missing", nil, "[missing]"); got != "[missing]" {
+		t.Errorf("expected fallback, got %q", got)
+	}
+
+	text, locale, err := b.GetPlural("de-AT", "cart.items", 2, nil)
+	if err != nil || text != "2 Artikel" || locale != "de" {
+		t.Errorf("unexpected plural %q from %s (%v)", text, locale, err)
+	}
+}
+
+func TestBundleGetPluralPrefersExactFormInChain(t *testing.T) {
+	b := NewBundle("en")
+	b.Add("de", Translations{"item_one": "ein Artikel", "item_other": "{{.Count}} Artikel"})
+	b.Add("de-AT", Translations{"item_other": "{{.Count}} Stück"})
+
+	tests := []struct {
+		n            int
+		text, locale string
+	}{
+		{1, "ein Artikel", "de"},
+		{3, "3 Stück", "de-AT"},
+	}
+	for _, tt := range tests {
+		text, locale, err := b.GetPlural("de-AT", "item", tt.n, nil)
+		if err != nil || text != tt.text || locale != tt.locale {
+			t.Errorf("GetPlural(de-AT, item, %d) = %q from %s (%v), want %q from %s", tt.n, text, locale, err, tt.text, tt.locale)
+		}
+	}
+}
+
+func TestBundleSetFallback(t *testing.T) {
+	b := NewBundle("en")
+	for _, tag := range []string{"en", "pt", "pt-PT"} {
+		b.Add(tag, Translations{"k": tag})
+	}
+	b.SetFallback("pt-BR", "pt-PT")
+	if got := b.Chain("pt-BR"); !reflect.DeepEqual(got, []string{"pt-PT", "pt", "en"}) {
+		t.Fatalf("unexpected chain %v", got)
+	}
+	// cycles are cut
+	b.SetFallback("pt", "pt-BR")
+	if got := b.Chain("pt"); !reflect.DeepEqual(got, []string{"pt", "pt-PT", "en"}) {
+		t.Fatalf("unexpected chain %v", got)
+	}
+}
+
+func TestBundleLoadFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "de-AT")
+	os.Mkdir(dir, 0755)
+	path := filepath.Join(dir, "common.json")
+	os.WriteFile(path, []byte(`{"greeting": "Servus"}`), 0644)
+	b := NewBundle("")
+	tag, err := b.LoadFile("", path)
+	if err != nil || tag != "de-AT" {
+		t.Fatalf("unexpected tag %q (%v)", tag, err)
+	}
+	if got := b.Locales(); !reflect.DeepEqual(got, []string{"de-AT"}) {
+		t.Fatalf("unexpected locales %v", got)
+	}
+}
+
+func TestCanonicalTag(t *testing.T) {
+	for in, want := range map[string]string{"de_at": "de-AT", "zh-hant-tw": "zh-Hant-TW", "EN": "en", "es-419": "es-419"} {
+		if got := CanonicalTag(in); got != want {
+			t.Errorf("%s: want %s, got %s", in, want, got)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package simpletrans
+
+import (
+	"sync"
+	"sync/atomic"
+	"text/template"
+
+	"github.com/mlechner911/i18ntool/internal/messageformat"
+)
+
+// maxCached bounds each parse cache of the package-level functions, so
+// callers that build Translations from changing input do not grow it without
+// limit. Values beyond it are parsed on every call.
+const maxCached = 4096
+
+// parseCache memoizes the parse of translation values by their text.
+// Failed parses are cached as well. It is safe for concurrent use.
+type parseCache[T any] struct {
+	parse func(string) (T, error)
+	m     sync.Map // string -> parsed[T]
+	n     atomic.Int64
+}
+
+type parsed[T any] struct {
+	v   T
+	err error
+}
+
+func (c *parseCache[T]) get(s string) (T, error) {
+	if p, ok := c.m.Load(s); ok {
+		p := p.(parsed[T])
+		return p.v, p.err
+	}
+	v, err := c.parse(s)
+	if c.n.Load() < maxCached {
+		if _, loaded := c.m.LoadOrStore(s, parsed[T]{v, err}); !loaded {
+			c.n.Add(1)
+		}
+	}
+	return v, err
+}
+
+var (
+	templates = &parseCache[*template.Template]{parse: func(s string) (*template.Template, error) {
+		return template.New("msg").Parse(s)
+	}}
+	messages = &parseCache[*messageformat.Message]{parse: messageformat.Parse}
+)
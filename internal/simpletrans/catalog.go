@@ -0,0 +1,180 @@
+package simpletrans
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/mlechner911/i18ntool/internal/messageformat"
+	"github.com/mlechner911/i18ntool/internal/plural"
+)
+
+// Catalog is a compiled, read-only form of Translations for hot paths. Keys
+// are flattened once when it is compiled. Templates and MessageFormat
+// patterns are parsed on first use and then cached. Results match the
+// package-level functions on the same Translations, including keys that
+// contain dots in the file ({"a.b": ...}). A Catalog is safe for concurrent
+// use.
+type Catalog struct {
+	language string
+	rules    *plural.Rules
+	entries  map[string]*catalogEntry
+}
+
+type catalogEntry struct {
+	raw        string
+	plain      string // raw with escapes resolved: the output without data
+	hasActions bool
+
+	tmplOnce sync.Once
+	tmpl     *template.Template
+
+	msgOnce sync.Once
+	msg     *messageformat.Message
+	msgErr  error
+}
+
+// Compile builds a catalog from t whose plural and MessageFormat rules follow
+// lang (English when unknown). Later changes to t are not seen.
+func Compile(t Translations, lang string) *Catalog {
+	rules, ok := plural.For(lang)
+	if !ok {
+		rules, _ = plural.For("en")
+	}
+	c := &Catalog{language: lang, rules: rules, entries: make(map[string]*catalogEntry)}
+	// a key reached through nested objects wins over a literal dotted key,
+	// as it does for lookup
+	literal := make(map[string]bool)
+	var walk func(m map[string]interface{}, prefix []string, dotted bool)
+	walk = func(m map[string]interface{}, prefix []string, dotted bool) {
+		for k, v := range m {
+			parts := append(append([]string(nil), prefix...), splitKey(k)...)
+			isDotted := dotted || strings.Contains(k, ".")
+			switch v := v.(type) {
+			case string:
+				key := strings.Join(parts, ".")
+				if _, ok := c.entries[key]; ok && (isDotted || !literal[key]) {
+					continue
+				}
+				c.entries[key] = &catalogEntry{raw: v, plain: unescapeCommon(v), hasActions: strings.Contains(v, "{{")}
+				literal[key] = isDotted
+			case map[string]interface{}:
+				walk(v, parts, isDotted)
+			case Translations:
+				walk(v, parts, isDotted)
+			}
+		}
+	}
+	walk(t, nil, false)
+	return c
+}
+
+// Language returns the language whose plural rules the catalog uses.
+func (c *Catalog) Language() string {
+	return c.language
+}
+
+// Keys returns the flattened keys in sorted order.
+func (c *Catalog) Keys() []string {
+	keys := make([]string, 0, len(c.entries))
+	for k := range c.entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Has reports whether key has a string value.
+func (c *Catalog) Has(key string) bool {
+	_, ok := c.entry(key)
+	return ok
+}
+
+func (c *Catalog) entry(key string) (*catalogEntry, bool) {
+	if e, ok := c.entries[key]; ok {
+		return e, true
+	}
+	if strings.Contains(key, "..") || strings.HasPrefix(key, ".") || strings.HasSuffix(key, ".") {
+		e, ok := c.entries[strings.Join(splitKey(key), ".")]
+		return e, ok
+	}
+	return nil, false
+}
+
+// Lookup returns the value of key rendered with data; ok is false when the
+// key is missing.
+func (c *Catalog) Lookup(key string, data map[string]interface{}) (string, bool) {
+	e, ok := c.entry(key)
+	if !ok {
+		return "", false
+	}
+	return e.render(data), true
+}
+
+// GetTranslation is GetTranslation on the catalog.
+func (c *Catalog) GetTranslation(key string, data map[string]interface{}, fallback string) string {
+	if text, ok := c.Lookup(key, data); ok {
+		return text
+	}
+	return fallback
+}
+
+// GetPlural is GetPlural on the catalog.
+func (c *Catalog) GetPlural(key string, n interface{}, data map[string]interface{}) (string, error) {
+	e, ok := c.pluralEntry(key, n)
+	if !ok {
+		return "", fmt.Errorf("no plural form of %q", key)
+	}
+	return e.render(pluralData(n, data)), nil
+}
+
+func (c *Catalog) pluralEntry(key string, n interface{}) (*catalogEntry, bool) {
+	for _, k := range c.pluralKeys(key, n) {
+		if e, ok := c.entry(k); ok {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// pluralKeys returns the keys GetPlural tries for n, in order: the form of
+// n's category, key_other and key itself.
+func (c *Catalog) pluralKeys(key string, n interface{}) []string {
+	return []string{key + "_" + c.rules.Select(n), key + "_other", key}
+}
+
+// FormatMessage is FormatMessage on the catalog.
+func (c *Catalog) FormatMessage(key string, args map[string]interface{}) (string, error) {
+	e, ok := c.entry(key)
+	if !ok {
+		return "", fmt.Errorf("translation %q not found", key)
+	}
+	e.msgOnce.Do(func() { e.msg, e.msgErr = messageformat.Parse(e.raw) })
+	if e.msgErr != nil {
+		return "", fmt.Errorf("%s: %w", key, e.msgErr)
+	}
+	out, err := e.msg.Format(c.language, args)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", key, err)
+	}
+	return out, nil
+}
+
+// render mirrors GetTranslation: the template runs only with data, and a
+// template that fails to parse or execute yields the unrendered value.
+func (e *catalogEntry) render(data map[string]interface{}) string {
+	if data == nil || !e.hasActions {
+		return e.plain
+	}
+	e.tmplOnce.Do(func() { e.tmpl, _ = template.New("msg").Parse(e.raw) })
+	if e.tmpl == nil {
+		return e.plain
+	}
+	var b strings.Builder
+	if err := e.tmpl.Execute(&b, data); err != nil {
+		return e.plain
+	}
+	return unescapeCommon(b.String())
+}
@@ -0,0 +1,181 @@
+package simpletrans
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func catalogFixture() Translations {
+	return Translations{
+		"greeting": "Hello {{.Name}}",
+		"plain":    `line\nbreak`,
+		"broken":   "Hi {{.Name",
+		"a.b":      "literal",
+		"a":        map[string]interface{}{"b": "nested", "c": "only nested"},
+		"x.y":      "only literal",
+		"user": map[string]interface{}{
+			"profile":        map[string]interface{}{"title": "Profile of {{.Name}}"},
+			"settings.title": "Settings",
+		},
+		"files_one":   "{{.Count}} plik",
+		"files_few":   "{{.Count}} pliki",
+		"files_many":  "{{.Count}} plików",
+		"files_other": "{{.Count}} pliku",
+		"inbox":       "{n, plural, one {# wiadomość} few {# wiadomości} other {# wiadomości}}",
+		"count":       42,
+	}
+}
+
+func TestCatalogMatchesTranslations(t *testing.T) {
+	tr := catalogFixture()
+	c := Compile(tr, "pl")
+	data := map[string]interface{}{"Name": "Ada"}
+	for _, key := range []string{"greeting", "plain", "broken", "a.b", "a.c", "x.y", "user.settings.title", "user.profile.title", "user..profile.title", "user.profile", "count", "missing"} {
+		for _, d := range []map[string]interface{}{nil, data} {
+			want, _ := GetTranslation(tr, key, d, "fallback")
+			if got := c.GetTranslation(key, d, "fallback"); got != want {
+				t.Errorf("%s with %v: catalog %q, translations %q", key, d, got, want)
+			}
+		}
+	}
+	if got, _ := GetTranslation(tr, "user.settings.title", nil, ""); got != "Settings" {
+		t.Errorf("literal dotted key: got %q", got)
+	}
+	for _, n := range []interface{}{1, 3, 5, 22, "1.5"} {
+		want, _ := GetPlural(tr, "pl", "files", n, nil)
+		if got, err := c.GetPlural("files", n, nil); err != nil || got != want {
+			t.Errorf("plural %v: catalog %q (%v), translations %q", n, got, err, want)
+		}
+		wantMsg, _ := FormatMessage(tr, "pl", "inbox", map[string]interface{}{"n": n})
+		if got, _ := c.FormatMessage("inbox", map[string]interface{}{"n": n}); got != wantMsg {
+			t.Errorf("message %v: catalog %q, translations %q", n, got, wantMsg)
+		}
+	}
+	if _, err := c.GetPlural("nope", 1, nil); err == nil {
+		t.Error("expected error for missing plural")
+	}
+	if c.Language() != "pl" {
+		t.Errorf("unexpected language %q", c.Language())
+	}
+}
+
+func TestCatalogIsImmutable(t *testing.T) {
+	tr := Translations{"k": "before"}
+	c := Compile(tr, "pl")
+	tr["k"] = "after"
+	if got := c.GetTranslation("k", nil, ""); got != "before" {
+		t.Errorf("catalog saw a later change: %q", got)
+	}
+}
+
+func TestCatalogConcurrent(t *testing.T) {
+	c := Compile(catalogFixture(), "pl")
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprint("user", i)
+			if got := c.GetTranslation("greeting", map[string]interface{}{"Name": name}, ""); got != "Hello "+name {
+				t.Errorf("unexpected %q", got)
+			}
+			if _, err := c.FormatMessage("inbox", map[string]interface{}{"n": i}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestPackageFunctionsCacheParses(t *testing.T) {
+	tr := Translations{"hi": "Hi {{.Name}} (cache test)", "bad": "{{.Name", "inbox": "{n, plural, one {# message} other {# messages}} (cache test)"}
+	for i := 0; i < 2; i++ {
+		if got, _ := GetTranslation(tr, "hi", map[string]interface{}{"Name": "Ada"}, ""); got != "Hi Ada (cache test)" {
+			t.Fatalf("GetTranslation = %q", got)
+		}
+		if got, _ := GetTranslation(tr, "bad", map[string]interface{}{"Name": "Ada"}, ""); got != "{{.Name" {
+			t.Fatalf("GetTranslation with a broken template = %q", got)
+		}
+		if got, err := FormatMessage(tr, "en", "inbox", map[string]interface{}{"n": 2}); err != nil || got != "2 messages (cache test)" {
+			t.Fatalf("FormatMessage = %q, %v", got, err)
+		}
+	}
+	for _, s := range []string{"Hi {{.Name}} (cache test)", "{{.Name"} {
+		if _, ok := templates.m.Load(s); !ok {
+			t.Errorf("template %q not cached", s)
+		}
+	}
+	if _, ok := messages.m.Load(tr["inbox"]); !ok {
+		t.Errorf("message not cached")
+	}
+}
+
+// The benchmarks compare the Translations path, which walks the nested map
+// on every call, with a compiled Catalog.
+
+func BenchmarkGetTranslation(b *testing.B) {
+	tr := catalogFixture()
+	data := map[string]interface{}{"Name": "Ada"}
+	for i := 0; i < b.N; i++ {
+		GetTranslation(tr, "user.profile.title", data, "")
+	}
+}
+
+func BenchmarkCatalogGetTranslation(b *testing.B) {
+	c := Compile(catalogFixture(), "pl")
+	data := map[string]interface{}{"Name": "Ada"}
+	for i := 0; i < b.N; i++ {
+		c.GetTranslation("user.profile.title", data, "")
+	}
+}
+
+func BenchmarkGetTranslationPlain(b *testing.B) {
+	tr := catalogFixture()
+	for i := 0; i < b.N; i++ {
+		GetTranslation(tr, "a.c", nil, "")
+	}
+}
+
+func BenchmarkCatalogGetTranslationPlain(b *testing.B) {
+	c := Compile(catalogFixture(), "pl")
+	for i := 0; i < b.N; i++ {
+		c.GetTranslation("a.c", nil, "")
+	}
+}
+
+func BenchmarkFormatMessage(b *testing.B) {
+	tr := catalogFixture()
+	args := map[string]interface{}{"n": 5}
+	for i := 0; i < b.N; i++ {
+		FormatMessage(tr, "pl", "inbox", args)
+	}
+}
+
+func BenchmarkCatalogFormatMessage(b *testing.B) {
+	c := Compile(catalogFixture(), "pl")
+	args := map[string]interface{}{"n": 5}
+	for i := 0; i < b.N; i++ {
+		c.FormatMessage("inbox", args)
+	}
+}
+
+func BenchmarkGetTranslationParallel(b *testing.B) {
+	tr := catalogFixture()
+	data := map[string]interface{}{"Name": "Ada"}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			GetTranslation(tr, "user.profile.title", data, "")
+		}
+	})
+}
+
+func BenchmarkCatalogGetTranslationParallel(b *testing.B) {
+	c := Compile(catalogFixture(), "pl")
+	data := map[string]interface{}{"Name": "Ada"}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			c.GetTranslation("user.profile.title", data, "")
+		}
+	})
+}
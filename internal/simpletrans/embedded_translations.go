@@ -1,104 +1,284 @@
-// Code generated by scripts/embed_locales_to_go.py; DO NOT EDIT.
+// Code generated by i18n-manager embed; DO NOT EDIT.
+
 package simpletrans
 
+// EmbeddedTranslations holds the flattened translations of each locale.
 var EmbeddedTranslations = map[string]map[string]string{
-	"de": map[string]string{
+	"de": {
 		"add.added":                   "Übersetzung hinzugefügt",
+		"add.all_done":                "%s zu %d Dateien hinzugefügt:\\n",
+		"add.all_item":                "  - %s: %q\\n",
 		"check.all_complete":          "Alle Übersetzungen vollständig!",
-		"check.found_missing_count":   "Gefunden %d fehlende Übersetzungen:\n\n",
+		"check.below_min_completion":  "%s ist zu %.1f%% vollständig, gefordert sind %.1f%%\\n",
+		"check.completion_item":       "  %s: %.1f%% (%d/%d übersetzt, %d unübersetzt, %d verwaist)\\n",
+		"check.duplicate_count":       "%d doppelte Schlüssel gefunden:\\n",
+		"check.found_missing_count":   "Gefunden %d fehlende Übersetzungen:\\n\\n",
+		"check.icu_arguments":         "  - %s [%s]: MessageFormat-Argumente weichen ab (erwartet %s, gefunden %s)\\n",
+		"check.icu_count":             "%d ICU-MessageFormat-Probleme gefunden:\\n",
+		"check.icu_syntax":            "  - %s [%s]: ungültiges MessageFormat: %s\\n",
 		"check.key_prefix":            "Schlüssel: %s: { ",
 		"check.key_suffix":            " }",
 		"check.lang_value":            "%s: %s",
+		"check.orphaned_count":        "\\n%d verwaiste Schlüssel in %s (nicht in %s):\\n",
+		"check.placeholder_count":     "Gefunden %d abweichende Platzhalter (Referenz: %s):\\n",
+		"check.placeholder_item":      "  - %s [%s]: %s (erwartet %s, gefunden %s)\\n",
+		"check.plural_count":          "%d Pluralschlüssel mit falschen Formen gefunden:\\n",
+		"check.plural_missing":        "  - %s (%s): fehlende Formen %s\\n",
+		"check.plural_no_rules":       "Keine CLDR-Pluralregeln für %s, seine Pluralformen werden nicht geprüft\\n",
+		"check.plural_rare":           "  - %s (%s): fehlende Formen, nur für große Zahlen oder Brüche verwendet: %s\\n",
+		"check.plural_unused":         "  - %s (%s): von der Sprache nicht verwendete Formen: %s\\n",
+		"check.source_header":         "Vollständigkeit gegenüber der Ausgangssprache %s:\\n",
+		"check.structure_count":       "%d Strukturkonflikte gefunden:\\n",
+		"check.structure_item":        "  - %s [%s]\\n",
+		"check.structure_location":    "      %s: %s bei %s\\n",
+		"check.untranslated_count":    "\\n%d unübersetzte Schlüssel in %s:\\n",
 		"common.button.cancel":        "Abbrechen",
 		"common.button.save":          "Speichern",
 		"common.greeting.hello":       "Hallo",
 		"common.greeting.welcome":     "Willkommen",
+		"convert.done":                "%s nach %s konvertiert\\n",
+		"copy.done":                   "%s nach %s kopiert:\\n",
 		"dashboard.title":             "Instrumententafel",
-		"error.general":               "Fehler: %v\n",
-		"error.loading_translations":  "Fehler beim Laden der Übersetzungen: %v\n",
-		"error.rendering_translation": "Fehler beim Rendern der Übersetzung: %v\n",
-		"error.unknown_command":       "Unbekannter Befehl: %s\n",
+		"delete.done":                 "%s gelöscht:\\n",
+		"edit.file_changed":           "  - %s: %d Schlüssel\\n",
+		"edit.file_unchanged":         "  - %s: unverändert\\n",
+		"embed.unchanged":             "%s ist aktuell (%d Sprachen)\\n",
+		"embed.written":               "%s geschrieben (%d Sprachen)\\n",
+		"error.general":               "Fehler: %v\\n",
+		"error.loading_translations":  "Fehler beim Laden der Übersetzungen: %v\\n",
+		"error.rendering_translation": "Fehler beim Rendern der Übersetzung: %v\\n",
+		"error.unknown_command":       "Unbekannter Befehl: %s\\n",
+		"error.unknown_format":        "Unbekanntes Format: %s (erwartet text, json, sarif oder junit)\\n",
 		"errors.network.offline":      "Sie sind offline",
 		"errors.network.timeout":      "Anforderung abgelaufen",
+		"gen.unchanged":               "%s ist aktuell\\n",
+		"gen.written":                 "%s geschrieben\\n",
+		"lint.found_count":            "%d Probleme gefunden:\\n",
+		"lint.item":                   "  %s:%d:%d: %s\\n",
+		"lint.ok":                     "Keine Probleme in %d Dateien gefunden.\\n",
+		"prune.dry_run_count":         "Würde %d unbenutzte Schlüssel aus %d Dateien entfernen:\\n",
+		"prune.kept_count":            "%d unbenutzte Schlüssel wegen --keep behalten:\\n",
+		"prune.nothing":               "Keine unbenutzten Schlüssel zu entfernen.",
+		"prune.removed_count":         "%d unbenutzte Schlüssel aus %d Dateien entfernt:\\n",
+		"rename.done":                 "%s in %s umbenannt (%d Dateien)\\n",
+		"rename.source_count":         "%d Verweise im Quellcode aktualisiert:\\n",
 		"simple.output_prefix":        "",
 		"sort.success":                "Übersetzungen sortiert und gespeichert.",
+		"undefined.all_defined":       "Alle verwendeten Schlüssel sind definiert!",
+		"undefined.found_count":       "Gefunden %d im Code verwendete Schlüssel, die in keiner Sprachdatei stehen:\\n",
+		"undefined.item":              "  - %s (%s:%d)\\n",
 		"unknown":                     "<unbekannt>",
 		"unused.all_used":             "Alle Schlüssel werden verwendet!",
-		"unused.found_count":          "Gefunden %d unbenutzte Schlüssel:\n",
-		"unused.item":                 "  - %s\n",
+		"unused.found_count":          "Gefunden %d unbenutzte Schlüssel:\\n",
+		"unused.item":                 "  - %s\\n",
 		"usage.add":                   "Verwendung: i18n-manager add <datei.json> <schluessel> <wert>",
-		"usage.check":                 "Verwendung: i18n-manager check <datei1.json> <datei2.json> [... ]",
+		"usage.add_all":               "Verwendung: i18n-manager add --all <datei1.json> <datei2.json> [... ] <schluessel> [sprache=wert ...] [--fill empty|todo|source] [--source <sprache>]",
+		"usage.check":                 "Verwendung: i18n-manager check <datei1.json> <datei2.json> [... ] [--source <lang> [--min-completion <percent>]] [--format text|json|sarif|junit]",
+		"usage.config":                "Sprachdateien und Vorgaben für --source, --format und die Quelltextsuche werden aus .i18ntool.yaml oder .i18ntool.json im aktuellen oder einem übergeordneten Verzeichnis gelesen (oder --config <datei>); Dateiargumente können dann entfallen.",
+		"usage.convert":               "Verwendung: i18n-manager convert <eingabe.json|.po|.pot> <ausgabe.po|.pot|.json> [--source <quelle.json>]",
+		"usage.copy":                  "Verwendung: i18n-manager copy <quell.schluessel> <ziel.schluessel> <datei1.json> <datei2.json> [... ]",
+		"usage.delete":                "Verwendung: i18n-manager delete <schluessel|praefix> <datei1.json> <datei2.json> [... ]",
+		"usage.embed":                 "Verwendung: i18n-manager embed <datei.json|muster> [... ] [--out <datei.go>] [--package <name>] [--var <Name>] [--fs]",
+		"usage.export_xliff":          "Verwendung: i18n-manager export-xliff <datei1.json> <datei2.json> [... ] [--source <sprache>] [--version 1.2|2.0] [--out <verzeichnis>]",
+		"usage.gen_go":                "Verwendung: i18n-manager gen-go <datei1.json> [... ] [--out <datei.go>] [--package <name>] [--source <sprache>]",
+		"usage.gen_ts":                "Verwendung: i18n-manager gen-ts <datei1.json> [... ] [--out <datei.d.ts>] [--source <sprache>] [--augment i18next|vue-i18n]",
 		"usage.general":               "Verwendung: i18n-manager <Befehl> [Optionen]",
+		"usage.import_xliff":          "Verwendung: i18n-manager import-xliff <datei.xlf> <datei1.json> <datei2.json> [... ] [--force]",
+		"usage.lint":                  "Verwendung: i18n-manager lint <datei1.json> [... ] [--format text|json|sarif|junit]",
+		"usage.prune":                 "Verwendung: i18n-manager prune <datei1.json> <datei2.json> -- <projekt-pfad> [... ] [--dry-run] [--keep muster] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N]",
+		"usage.rename":                "Verwendung: i18n-manager rename <alter.schluessel> <neuer.schluessel> <datei1.json> [... ] [--update-source <pfad>] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N]",
 		"usage.simple":                "Verwendung: i18n-manager simple <translation.json> <schluessel> [<fallback>]",
 		"usage.sort":                  "Verwendung: i18n-manager sort <datei1.json> <datei2.json> [... ]",
-		"usage.unused":                "Verwendung: i18n-manager unused <datei1.json> <datei2.json> -- <projekt-pfad> [... ]",
+		"usage.undefined":             "Verwendung: i18n-manager undefined <datei1.json> <datei2.json> -- <projekt-pfad> [... ] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N] [--format text|json|sarif|junit]",
+		"usage.unused":                "Verwendung: i18n-manager unused <datei1.json> <datei2.json> -- <projekt-pfad> [... ] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N] [--format text|json|sarif|junit]",
 		"user.profile.age":            "Alter",
 		"user.profile.name":           "Name",
+		"xliff.conflict_count":        "%d Konflikte wurden nicht übernommen (--force zum Überschreiben):\\n",
+		"xliff.conflict_item":         "  - %s: %s (aktuell %q, neu %q)\\n",
+		"xliff.imported":              "%d Übersetzungen in %s importiert\\n",
+		"xliff.written":               "%s geschrieben\\n",
 	},
-	"en": map[string]string{
+	"en": {
 		"add.added":                   "Added translation",
+		"add.all_done":                "Added %s to %d files:\\n",
+		"add.all_item":                "  - %s: %q\\n",
 		"check.all_complete":          "All translations complete!",
-		"check.found_missing_count":   "Found %d missing translations:\n\n",
+		"check.below_min_completion":  "%s is %.1f%% complete, below the required %.1f%%\\n",
+		"check.completion_item":       "  %s: %.1f%% (%d/%d translated, %d untranslated, %d orphaned)\\n",
+		"check.duplicate_count":       "Found %d duplicate keys:\\n",
+		"check.found_missing_count":   "Found %d missing translations:\\n\\n",
+		"check.icu_arguments":         "  - %s [%s]: MessageFormat arguments differ (expected %s, found %s)\\n",
+		"check.icu_count":             "Found %d ICU MessageFormat problems:\\n",
+		"check.icu_syntax":            "  - %s [%s]: invalid MessageFormat: %s\\n",
 		"check.key_prefix":            "key: %s: { ",
 		"check.key_suffix":            " }",
 		"check.lang_value":            "%s: %s",
+		"check.orphaned_count":        "\\n%d orphaned keys in %s (not in %s):\\n",
+		"check.placeholder_count":     "Found %d placeholder mismatches (reference: %s):\\n",
+		"check.placeholder_item":      "  - %s [%s]: %s (expected %s, found %s)\\n",
+		"check.plural_count":          "Found %d plural keys with wrong forms:\\n",
+		"check.plural_missing":        "  - %s (%s): missing forms %s\\n",
+		"check.plural_no_rules":       "No CLDR plural rules for %s, so its plural forms are not checked\\n",
+		"check.plural_rare":           "  - %s (%s): missing forms only used for large numbers or fractions: %s\\n",
+		"check.plural_unused":         "  - %s (%s): forms not used by the language: %s\\n",
+		"check.source_header":         "Completion against source language %s:\\n",
+		"check.structure_count":       "Found %d structural conflicts:\\n",
+		"check.structure_item":        "  - %s [%s]\\n",
+		"check.structure_location":    "      %s: %s at %s\\n",
+		"check.untranslated_count":    "\\n%d untranslated keys in %s:\\n",
 		"common.greeting.hello":       "Hello",
-		"error.general":               "Error: %v\n",
-		"error.loading_translations":  "Error loading translations: %v\n",
-		"error.rendering_translation": "Error rendering translation: %v\n",
-		"error.unknown_command":       "Unknown command: %s\n",
+		"convert.done":                "Converted %s -> %s\\n",
+		"copy.done":                   "Copied %s to %s:\\n",
+		"delete.done":                 "Deleted %s:\\n",
+		"edit.file_changed":           "  - %s: %d keys\\n",
+		"edit.file_unchanged":         "  - %s: unchanged\\n",
+		"embed.unchanged":             "%s is up to date (%d locales)\\n",
+		"embed.written":               "Wrote %s (%d locales)\\n",
+		"error.general":               "Error: %v\\n",
+		"error.loading_translations":  "Error loading translations: %v\\n",
+		"error.rendering_translation": "Error rendering translation: %v\\n",
+		"error.unknown_command":       "Unknown command: %s\\n",
+		"error.unknown_format":        "Unknown format: %s (expected text, json, sarif or junit)\\n",
+		"gen.unchanged":               "%s is up to date\\n",
+		"gen.written":                 "Wrote %s\\n",
+		"lint.found_count":            "Found %d problems:\\n",
+		"lint.item":                   "  %s:%d:%d: %s\\n",
+		"lint.ok":                     "No problems found in %d files.\\n",
+		"prune.dry_run_count":         "Would remove %d unused keys from %d files:\\n",
+		"prune.kept_count":            "Kept %d unused keys matching --keep:\\n",
+		"prune.nothing":               "No unused keys to remove.",
+		"prune.removed_count":         "Removed %d unused keys from %d files:\\n",
+		"rename.done":                 "Renamed %s to %s in %d files\\n",
+		"rename.source_count":         "Updated %d source references:\\n",
 		"simple.output_prefix":        "",
 		"sort.success":                "Sorted and saved translations.",
+		"undefined.all_defined":       "All referenced keys are defined!",
+		"undefined.found_count":       "Found %d keys used in code but missing from every locale:\\n",
+		"undefined.item":              "  - %s (%s:%d)\\n",
 		"unknown":                     "<unknown>",
 		"unused.all_used":             "All keys are used!",
-		"unused.found_count":          "Found %d unused keys:\n",
-		"unused.item":                 "  - %s\n",
+		"unused.found_count":          "Found %d unused keys:\\n",
+		"unused.item":                 "  - %s\\n",
 		"usage.add":                   "Usage: i18n-manager add <file.json> <key> <value>",
-		"usage.check":                 "Usage: i18n-manager check <file1.json> <file2.json> [... ]",
+		"usage.add_all":               "Usage: i18n-manager add --all <file1.json> <file2.json> [... ] <key> [lang=value ...] [--fill empty|todo|source] [--source <lang>]",
+		"usage.check":                 "Usage: i18n-manager check <file1.json> <file2.json> [... ] [--source <lang> [--min-completion <percent>]] [--format text|json|sarif|junit]",
+		"usage.config":                "Locale files and defaults for --source, --format and the source scan are read from .i18ntool.yaml or .i18ntool.json in the current or a parent directory (or --config <file>); file arguments may then be left out.",
+		"usage.convert":               "Usage: i18n-manager convert <input.json|.po|.pot> <output.po|.pot|.json> [--source <source.json>]",
+		"usage.copy":                  "Usage: i18n-manager copy <src.key> <dst.key> <file1.json> <file2.json> [... ]",
+		"usage.delete":                "Usage: i18n-manager delete <key|prefix> <file1.json> <file2.json> [... ]",
+		"usage.embed":                 "Usage: i18n-manager embed <file.json|glob> [... ] [--out <file.go>] [--package <name>] [--var <Name>] [--fs]",
+		"usage.export_xliff":          "Usage: i18n-manager export-xliff <file1.json> <file2.json> [... ] [--source <lang>] [--version 1.2|2.0] [--out <dir>]",
+		"usage.gen_go":                "Usage: i18n-manager gen-go <file1.json> [... ] [--out <file.go>] [--package <name>] [--source <lang>]",
+		"usage.gen_ts":                "Usage: i18n-manager gen-ts <file1.json> [... ] [--out <file.d.ts>] [--source <lang>] [--augment i18next|vue-i18n]",
 		"usage.general":               "Usage: i18n-manager <command> [options]",
+		"usage.import_xliff":          "Usage: i18n-manager import-xliff <file.xlf> <file1.json> <file2.json> [... ] [--force]",
+		"usage.lint":                  "Usage: i18n-manager lint <file1.json> [... ] [--format text|json|sarif|junit]",
+		"usage.prune":                 "Usage: i18n-manager prune <file1.json> <file2.json> -- <project-path> [... ] [--dry-run] [--keep pattern] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N]",
+		"usage.rename":                "Usage: i18n-manager rename <old.key> <new.key> <file1.json> [... ] [--update-source <path>] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N]",
 		"usage.simple":                "Usage: i18n-manager simple <translation.json> <key> [<fallback>]",
 		"usage.sort":                  "Usage: i18n-manager sort <file1.json> <file2.json> [... ]",
-		"usage.unused":                "Usage: i18n-manager unused <file1.json> <file2.json> -- <project-path> [... ]",
+		"usage.undefined":             "Usage: i18n-manager undefined <file1.json> <file2.json> -- <project-path> [... ] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N] [--format text|json|sarif|junit]",
+		"usage.unused":                "Usage: i18n-manager unused <file1.json> <file2.json> -- <project-path> [... ] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N] [--format text|json|sarif|junit]",
+		"xliff.conflict_count":        "%d conflicts were not applied (use --force to overwrite):\\n",
+		"xliff.conflict_item":         "  - %s: %s (current %q, incoming %q)\\n",
+		"xliff.imported":              "Imported %d translations into %s\\n",
+		"xliff.written":               "Wrote %s\\n",
 	},
-	"es": map[string]string{
+	"es": {
 		"add.added":                   "Traducción añadida",
+		"add.all_done":                "%s añadida a %d archivos:\\n",
+		"add.all_item":                "  - %s: %q\\n",
 		"check.all_complete":          "¡Todas las traducciones están completas!",
-		"check.found_missing_count":   "Encontradas %d traducciones faltantes:\n\n",
+		"check.below_min_completion":  "%s está completo al %.1f%%, por debajo del %.1f%% requerido\\n",
+		"check.completion_item":       "  %s: %.1f%% (%d/%d traducidas, %d sin traducir, %d huérfanas)\\n",
+		"check.duplicate_count":       "Se encontraron %d claves duplicadas:\\n",
+		"check.found_missing_count":   "Encontradas %d traducciones faltantes:\\n\\n",
+		"check.icu_arguments":         "  - %s [%s]: los argumentos de MessageFormat difieren (esperado %s, encontrado %s)\\n",
+		"check.icu_count":             "Se encontraron %d problemas de ICU MessageFormat:\\n",
+		"check.icu_syntax":            "  - %s [%s]: MessageFormat no válido: %s\\n",
 		"check.key_prefix":            "clave: %s: { ",
 		"check.key_suffix":            " }",
 		"check.lang_value":            "%s: %s",
+		"check.orphaned_count":        "\\n%d claves huérfanas en %s (no están en %s):\\n",
+		"check.placeholder_count":     "Encontrados %d marcadores de posición distintos (referencia: %s):\\n",
+		"check.placeholder_item":      "  - %s [%s]: %s (esperado %s, encontrado %s)\\n",
+		"check.plural_count":          "Se encontraron %d claves plurales con formas incorrectas:\\n",
+		"check.plural_missing":        "  - %s (%s): faltan las formas %s\\n",
+		"check.plural_no_rules":       "No hay reglas de plural CLDR para %s, sus formas de plural no se comprueban\\n",
+		"check.plural_rare":           "  - %s (%s): faltan formas usadas solo para números grandes o fracciones: %s\\n",
+		"check.plural_unused":         "  - %s (%s): formas que el idioma no usa: %s\\n",
+		"check.source_header":         "Progreso respecto al idioma de origen %s:\\n",
+		"check.structure_count":       "Encontrados %d conflictos de estructura:\\n",
+		"check.structure_item":        "  - %s [%s]\\n",
+		"check.structure_location":    "      %s: %s en %s\\n",
+		"check.untranslated_count":    "\\n%d claves sin traducir en %s:\\n",
 		"common.button.cancel":        "Cancelar",
 		"common.button.save":          "Guardar",
 		"common.greeting.hello":       "Hola",
 		"common.greeting.welcome":     "Bienvenido",
+		"convert.done":                "%s convertido a %s\\n",
+		"copy.done":                   "%s copiada a %s:\\n",
 		"dashboard.title":             "Tablero",
-		"error.general":               "Error: %v\n",
-		"error.loading_translations":  "Error al cargar traducciones: %v\n",
-		"error.rendering_translation": "Error al renderizar la traducción: %v\n",
-		"error.unknown_command":       "Comando desconocido: %s\n",
+		"delete.done":                 "%s eliminada:\\n",
+		"edit.file_changed":           "  - %s: %d claves\\n",
+		"edit.file_unchanged":         "  - %s: sin cambios\\n",
+		"embed.unchanged":             "%s está actualizado (%d idiomas)\\n",
+		"embed.written":               "Escrito %s (%d idiomas)\\n",
+		"error.general":               "Error: %v\\n",
+		"error.loading_translations":  "Error al cargar traducciones: %v\\n",
+		"error.rendering_translation": "Error al renderizar la traducción: %v\\n",
+		"error.unknown_command":       "Comando desconocido: %s\\n",
+		"error.unknown_format":        "Formato desconocido: %s (se esperaba text, json, sarif o junit)\\n",
 		"errors.network.offline":      "Estás desconectado",
 		"errors.network.timeout":      "Solicitud agotada",
+		"gen.unchanged":               "%s está actualizado\\n",
+		"gen.written":                 "Escrito %s\\n",
+		"lint.found_count":            "Se encontraron %d problemas:\\n",
+		"lint.item":                   "  %s:%d:%d: %s\\n",
+		"lint.ok":                     "No se encontraron problemas en %d archivos.\\n",
+		"prune.dry_run_count":         "Se eliminarían %d claves sin usar de %d archivos:\\n",
+		"prune.kept_count":            "Conservadas %d claves sin usar por --keep:\\n",
+		"prune.nothing":               "No hay claves sin usar que eliminar.",
+		"prune.removed_count":         "Eliminadas %d claves sin usar de %d archivos:\\n",
+		"rename.done":                 "%s renombrada a %s en %d archivos\\n",
+		"rename.source_count":         "Actualizadas %d referencias en el código:\\n",
 		"simple.output_prefix":        "",
 		"sort.success":                "Traducciones ordenadas y guardadas.",
+		"undefined.all_defined":       "¡Todas las claves usadas están definidas!",
+		"undefined.found_count":       "Encontradas %d claves usadas en el código que no existen en ningún idioma:\\n",
+		"undefined.item":              "  - %s (%s:%d)\\n",
 		"unknown":                     "<desconocido>",
 		"unused.all_used":             "¡Todas las claves están usadas!",
-		"unused.found_count":          "Encontradas %d claves sin usar:\n",
-		"unused.item":                 "  - %s\n",
+		"unused.found_count":          "Encontradas %d claves sin usar:\\n",
+		"unused.item":                 "  - %s\\n",
 		"usage.add":                   "Uso: i18n-manager add <archivo.json> <clave> <valor>",
-		"usage.check":                 "Uso: i18n-manager check <archivo1.json> <archivo2.json> [... ]",
+		"usage.add_all":               "Uso: i18n-manager add --all <archivo1.json> <archivo2.json> [... ] <clave> [idioma=valor ...] [--fill empty|todo|source] [--source <idioma>]",
+		"usage.check":                 "Uso: i18n-manager check <archivo1.json> <archivo2.json> [... ] [--source <lang> [--min-completion <percent>]] [--format text|json|sarif|junit]",
+		"usage.config":                "Los archivos de idioma y los valores por defecto de --source, --format y el análisis del código se leen de .i18ntool.yaml o .i18ntool.json en el directorio actual o uno superior (o --config <archivo>); los archivos pueden omitirse.",
+		"usage.convert":               "Uso: i18n-manager convert <entrada.json|.po|.pot> <salida.po|.pot|.json> [--source <origen.json>]",
+		"usage.copy":                  "Uso: i18n-manager copy <clave.origen> <clave.destino> <archivo1.json> <archivo2.json> [... ]",
+		"usage.delete":                "Uso: i18n-manager delete <clave|prefijo> <archivo1.json> <archivo2.json> [... ]",
+		"usage.embed":                 "Uso: i18n-manager embed <archivo.json|patrón> [... ] [--out <archivo.go>] [--package <nombre>] [--var <Nombre>] [--fs]",
+		"usage.export_xliff":          "Uso: i18n-manager export-xliff <archivo1.json> <archivo2.json> [... ] [--source <idioma>] [--version 1.2|2.0] [--out <directorio>]",
+		"usage.gen_go":                "Uso: i18n-manager gen-go <archivo1.json> [... ] [--out <archivo.go>] [--package <nombre>] [--source <idioma>]",
+		"usage.gen_ts":                "Uso: i18n-manager gen-ts <archivo1.json> [... ] [--out <archivo.d.ts>] [--source <idioma>] [--augment i18next|vue-i18n]",
 		"usage.general":               "Uso: i18n-manager <comando> [opciones]",
+		"usage.import_xliff":          "Uso: i18n-manager import-xliff <archivo.xlf> <archivo1.json> <archivo2.json> [... ] [--force]",
+		"usage.lint":                  "Uso: i18n-manager lint <archivo1.json> [... ] [--format text|json|sarif|junit]",
+		"usage.prune":                 "Uso: i18n-manager prune <archivo1.json> <archivo2.json> -- <ruta-proyecto> [... ] [--dry-run] [--keep patrón] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N]",
+		"usage.rename":                "Uso: i18n-manager rename <clave.antigua> <clave.nueva> <archivo1.json> [... ] [--update-source <ruta>] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N]",
 		"usage.simple":                "Uso: i18n-manager simple <translation.json> <clave> [<fallback>]",
 		"usage.sort":                  "Uso: i18n-manager sort <archivo1.json> <archivo2.json> [... ]",
-		"usage.unused":                "Uso: i18n-manager unused <archivo1.json> <archivo2.json> -- <ruta-proyecto> [... ]",
+		"usage.undefined":             "Uso: i18n-manager undefined <archivo1.json> <archivo2.json> -- <ruta-proyecto> [... ] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N] [--format text|json|sarif|junit]",
+		"usage.unused":                "Uso: i18n-manager unused <archivo1.json> <archivo2.json> -- <ruta-proyecto> [... ] [--preset name] [--pattern glob=regex] [--skip dir] [--go-func Name:N] [--format text|json|sarif|junit]",
 		"user.profile.age":            "Edad",
 		"user.profile.name":           "Nombre",
+		"xliff.conflict_count":        "%d conflictos no se aplicaron (use --force para sobrescribir):\\n",
+		"xliff.conflict_item":         "  - %s: %s (actual %q, nuevo %q)\\n",
+		"xliff.imported":              "Importadas %d traducciones en %s\\n",
+		"xliff.written":               "Escrito %s\\n",
 	},
-	"example_new": map[string]string{
-		"button.cancel":   "Cancel",
-		"button.save":     "Save",
-		"welcome.message": "Welcome to the example locale",
-	},
-	"fr": map[string]string{
+	"fr": {
 		"common.button.cancel":    "Annuler",
 		"common.button.save":      "Enregistrer",
 		"common.greeting.hello":   "Bonjour",
@@ -0,0 +1,73 @@
+package simpletrans
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// LoadFS loads the files of fsys matching pattern (see fs.Glob), keyed by
+// language. This works with an embed.FS from //go:embed locales/*.json or an
+// fstest.MapFS in tests. The language comes from LanguageFromPath, or from
+// the file name when that finds none. Files of one language, such as
+// de/common.json and de/errors.json, are merged. A top-level key in two of
+// them is an error. Files are decoded strictly, as by LoadTranslationsStrict.
+func LoadFS(fsys fs.FS, pattern string) (map[string]Translations, error) {
+	paths, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no files match %s", pattern)
+	}
+	out := make(map[string]Translations)
+	origin := make(map[string]map[string]string) // language → key → file
+	for _, p := range paths {
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", p, err)
+		}
+		t, err := decodeStrict(p, data)
+		if err != nil {
+			return nil, err
+		}
+		lang := LanguageFromPath(p)
+		if lang == "" {
+			lang = strings.TrimSuffix(path.Base(p), path.Ext(p))
+		}
+
+		merged, ok := out[lang]
+		if !ok {
+			merged = make(Translations, len(t))
+			out[lang] = merged
+			origin[lang] = make(map[string]string, len(t))
+		}
+		for k, v := range t {
+			if prev, dup := origin[lang][k]; dup {
+				return nil, fmt.Errorf("%s: key %q is also defined in %s", p, k, prev)
+			}
+			merged[k] = v
+			origin[lang][k] = p
+		}
+	}
+	return out, nil
+}
+
+// AddFS loads the files of fsys matching pattern with LoadFS and adds each
+// language to the bundle. It returns the added tags in sorted order.
+func (b *Bundle) AddFS(fsys fs.FS, pattern string) ([]string, error) {
+	sets, err := LoadFS(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(sets))
+	for lang, t := range sets {
+		tag := CanonicalTag(lang)
+		b.Add(tag, t)
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
@@ -0,0 +1,75 @@
+package simpletrans
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLanguageFromPath(t *testing.T) {
+	tests := map[string]string{
+		"locales/de.json":        "de",
+		"locales/pt_BR.json":     "pt_BR",
+		"locales/de/common.json": "de",
+		"locales/messages.json":  "",
+	}
+	for p, want := range tests {
+		if got := LanguageFromPath(p); got != want {
+			t.Errorf("LanguageFromPath(%q) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json":        {Data: []byte(`{"greeting": "Hello", "items_one": "{{.Count}} item", "items_other": "{{.Count}} items"}`)},
+		"locales/pl/common.json": {Data: []byte(`{"greeting": "Cześć"}`)},
+		"locales/pl/cart.json":   {Data: []byte(`{"items_one": "{{.Count}} rzecz", "items_few": "{{.Count}} rzeczy", "items_many": "{{.Count}} rzeczy", "items_other": "{{.Count}} rzeczy"}`)},
+	}
+	sets, err := LoadFS(fsys, "locales/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 1 || len(sets["en"]) != 3 {
+		t.Fatalf("unexpected sets %v", sets)
+	}
+
+	b := NewBundle("en")
+	tags, err := b.AddFS(fsys, "locales/*/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.AddFS(fsys, "locales/*.json"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tags, []string{"pl"}) || !reflect.DeepEqual(b.Locales(), []string{"en", "pl"}) {
+		t.Fatalf("unexpected tags %v, locales %v", tags, b.Locales())
+	}
+	if got := b.GetTranslation("pl", "greeting", nil, ""); got != "Cześć" {
+		t.Errorf("unexpected greeting %q", got)
+	}
+	if got, _, err := b.GetPlural("pl", "items", 3, nil); err != nil || got != "3 rzeczy" {
+		t.Errorf("unexpected plural %q (%v)", got, err)
+	}
+}
+
+func TestLoadFSErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"de/a.json":   {Data: []byte(`{"k": "a"}`)},
+		"de/b.json":   {Data: []byte(`{"k": "b"}`)},
+		"fr.json":     {Data: []byte(`{"k": "a", "k": "b"}`)},
+		"broken.json": {Data: []byte(`{`)},
+	}
+	tests := map[string]string{
+		"de/*.json":      `key "k" is also defined in de/a.json`,
+		"fr.json":        "duplicate",
+		"broken.json":    "decode broken.json",
+		"missing/*.json": "no files match",
+	}
+	for pattern, want := range tests {
+		if _, err := LoadFS(fsys, pattern); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected error containing %q, got %v", pattern, want, err)
+		}
+	}
+}
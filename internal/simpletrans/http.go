@@ -0,0 +1,36 @@
+package simpletrans
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying l.
+func NewContext(ctx context.Context, l *Localizer) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the localizer stored by Middleware or NewContext.
+func FromContext(ctx context.Context) (*Localizer, bool) {
+	l, ok := ctx.Value(contextKey{}).(*Localizer)
+	return l, ok
+}
+
+// Middleware negotiates the request's Accept-Language header against the
+// bundle and stores a Localizer for the chosen locale in the request
+// context. It sets Content-Language and adds Accept-Language to Vary so
+// caches keep the variants apart.
+func Middleware(b *Bundle) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tag := b.Match(r.Header.Get("Accept-Language"))
+			if tag != "" {
+				w.Header().Set("Content-Language", tag)
+			}
+			w.Header().Add("Vary", "Accept-Language")
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), b.Localizer(tag))))
+		})
+	}
+}
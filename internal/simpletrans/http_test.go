@@ -0,0 +1,81 @@
+package simpletrans
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	got := ParseAcceptLanguage("en;q=0.5, de-at, fr;q=bad, de;q=0.9, *;q=0.1,, es;q=0")
+	want := []LanguagePreference{{"de-AT", 1}, {"de", 0.9}, {"en", 0.5}, {"*", 0.1}, {"es", 0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected preferences %v", got)
+	}
+}
+
+func TestBundleMatch(t *testing.T) {
+	b := NewBundle("en")
+	for _, tag := range []string{"en", "de", "pt-BR", "pt-PT"} {
+		b.Add(tag, Translations{"k": tag})
+	}
+	tests := []struct{ header, want string }{
+		{"de-CH, en;q=0.8", "de"},
+		{"fr, de;q=0.3", "de"},
+		{"pt", "pt-BR"},
+		{"pt-PT", "pt-PT"},
+		{"de;q=0, en-GB", "en"},
+		{"*, de;q=0.5", "en"},
+		{"de;q=0.5, *;q=0.1", "de"},
+		{"fr, *", "en"},
+		{"", "en"},
+	}
+	for _, tt := range tests {
+		if got := b.Match(tt.header); got != tt.want {
+			t.Errorf("Match(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	b := NewBundle("en")
+	b.Add("en", Translations{"greeting": "Hello {{.Name}}", "cart": map[string]interface{}{"items_one": "{{.Count}} item", "items_other": "{{.Count}} items"}})
+	b.Add("de", Translations{"greeting": "Hallo {{.Name}}"})
+
+	tmpl := template.Must(template.New("page").Funcs(b.Localizer("en").FuncMap()).Parse(`{{t "greeting" "Name" "Ada"}} / {{tn "cart.items" 3}} / {{t "missing"}}`))
+	handler := Middleware(b)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l, ok := FromContext(r.Context())
+		if !ok {
+			t.Fatal("no localizer in context")
+		}
+		if err := template.Must(tmpl.Clone()).Funcs(l.FuncMap()).Execute(w, nil); err != nil {
+			t.Fatal(err)
+		}
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9,en;q=0.8")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Body.String(); got != "Hallo Ada / 3 items / missing" {
+		t.Errorf("unexpected body %q", got)
+	}
+	if got := rec.Header().Get("Content-Language"); got != "de" {
+		t.Errorf("unexpected Content-Language %q", got)
+	}
+	if got := rec.Header().Get("Vary"); !strings.Contains(got, "Accept-Language") {
+		t.Errorf("unexpected Vary %q", got)
+	}
+}
+
+func TestFuncMapArguments(t *testing.T) {
+	l := NewBundle("en").Localizer("en")
+	tmpl := template.Must(template.New("x").Funcs(l.FuncMap()).Parse(`{{t "k" "Name"}}`))
+	if err := tmpl.Execute(&strings.Builder{}, nil); err == nil {
+		t.Error("expected error for odd name/value arguments")
+	}
+}
@@ -0,0 +1,89 @@
+package simpletrans
+
+import "fmt"
+
+// Localizer binds a Bundle to one requested locale. Its methods never fail:
+// a missing key renders as the key itself so gaps show up in the page.
+type Localizer struct {
+	bundle *Bundle
+	tag    string
+}
+
+// Localizer returns a localizer resolving lookups for tag through the
+// bundle's fallback chain.
+func (b *Bundle) Localizer(tag string) *Localizer {
+	return &Localizer{bundle: b, tag: CanonicalTag(tag)}
+}
+
+// Tag returns the requested locale.
+func (l *Localizer) Tag() string {
+	return l.tag
+}
+
+// T returns the translation of key rendered with data.
+func (l *Localizer) T(key string, data map[string]interface{}) string {
+	return l.bundle.GetTranslation(l.tag, key, data, key)
+}
+
+// Plural returns the plural form of key for n; see GetPlural.
+func (l *Localizer) Plural(key string, n interface{}, data map[string]interface{}) string {
+	text, _, err := l.bundle.GetPlural(l.tag, key, n, data)
+	if err != nil {
+		return key
+	}
+	return text
+}
+
+// Format renders key as ICU MessageFormat; see FormatMessage.
+func (l *Localizer) Format(key string, args map[string]interface{}) string {
+	text, _, err := l.bundle.FormatMessage(l.tag, key, args)
+	if err != nil {
+		return key
+	}
+	return text
+}
+
+// FuncMap returns template functions bound to the localizer, for
+// text/template and html/template alike:
+//
+//	{{t "nav.home"}}  {{t "welcome" "Name" .User}}
+//	{{tn "cart.items" .Count}}  {{tm "inbox" "n" .Unread}}
+//
+// Arguments after the key (and count) are name/value pairs. Templates parse
+// with any localizer's functions; per request, Clone the template and call
+// Funcs with the request's localizer.
+func (l *Localizer) FuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"t": func(key string, pairs ...interface{}) (string, error) {
+			data, err := pairsToMap(pairs)
+			return l.T(key, data), err
+		},
+		"tn": func(key string, n interface{}, pairs ...interface{}) (string, error) {
+			data, err := pairsToMap(pairs)
+			return l.Plural(key, n, data), err
+		},
+		"tm": func(key string, pairs ...interface{}) (string, error) {
+			args, err := pairsToMap(pairs)
+			return l.Format(key, args), err
+		},
+	}
+}
+
+// pairsToMap turns "name", value, ... into a map.
+func pairsToMap(pairs []interface{}) (map[string]interface{}, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("odd number of name/value arguments")
+	}
+	m := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		name, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("argument name %v is not a string", pairs[i])
+		}
+		m[name] = pairs[i+1]
+	}
+	return m, nil
+}
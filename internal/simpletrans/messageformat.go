@@ -0,0 +1,24 @@
+package simpletrans
+
+import "fmt"
+
+// FormatMessage renders the value of key as ICU MessageFormat, e.g.
+// "{count, plural, one {# item} other {# items}}", so Go code formats the
+// same strings as a formatjs or vue-i18n frontend. Plural rules follow lang,
+// as in GetPlural. Unlike GetTranslation there is no fallback: a missing
+// key, a syntax error or a missing argument is returned as error.
+func FormatMessage(t Translations, lang, key string, args map[string]interface{}) (string, error) {
+	s, ok := lookup(t, key).(string)
+	if !ok {
+		return "", fmt.Errorf("translation %q not found", key)
+	}
+	m, err := messages.get(s)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", key, err)
+	}
+	out, err := m.Format(lang, args)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", key, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,96 @@
+package simpletrans
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LanguagePreference is one entry of an Accept-Language header.
+type LanguagePreference struct {
+	Tag string  // canonical tag, or "*"
+	Q   float64 // quality between 0 and 1
+}
+
+// ParseAcceptLanguage parses an Accept-Language header such as
+// "de-AT, de;q=0.9, en;q=0.5" into preferences ordered by descending q, with
+// the header order kept for equal q. Malformed entries are skipped.
+func ParseAcceptLanguage(header string) []LanguagePreference {
+	var prefs []LanguagePreference
+	for _, entry := range strings.Split(header, ",") {
+		params := strings.Split(entry, ";")
+		tag := strings.TrimSpace(params[0])
+		if tag == "" {
+			continue
+		}
+		pref := LanguagePreference{Tag: tag, Q: 1}
+		if tag != "*" {
+			pref.Tag = CanonicalTag(tag)
+		}
+		valid := true
+		for _, p := range params[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(p), "=")
+			if strings.TrimSpace(name) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				valid = false
+				break
+			}
+			pref.Q = q
+		}
+		if valid {
+			prefs = append(prefs, pref)
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].Q > prefs[j].Q })
+	return prefs
+}
+
+// Match negotiates an Accept-Language header against the loaded locales and
+// returns the best one. For each preference, from highest q, it takes an
+// exact match, then a loaded parent (de-CH → de), then another region of the
+// same language (de → de-AT). Entries with q=0 are ignored; "*" and no match
+// select the default locale.
+func (b *Bundle) Match(acceptLanguage string) string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, pref := range ParseAcceptLanguage(acceptLanguage) {
+		if pref.Q == 0 {
+			continue
+		}
+		if pref.Tag == "*" {
+			break
+		}
+		if tag, ok := b.match(pref.Tag); ok {
+			return tag
+		}
+	}
+	return b.defaultLocale
+}
+
+func (b *Bundle) match(tag string) (string, bool) {
+	for t := tag; t != ""; {
+		if _, ok := b.locales[t]; ok {
+			return t, true
+		}
+		i := strings.LastIndex(t, "-")
+		if i < 0 {
+			break
+		}
+		t = t[:i]
+	}
+	base, _, _ := strings.Cut(tag, "-")
+	var siblings []string
+	for loaded := range b.locales {
+		if strings.HasPrefix(loaded, base+"-") {
+			siblings = append(siblings, loaded)
+		}
+	}
+	if len(siblings) == 0 {
+		return "", false
+	}
+	sort.Strings(siblings)
+	return siblings[0], true
+}
@@ -0,0 +1,48 @@
+package simpletrans
+
+import (
+	"fmt"
+
+	"github.com/mlechner911/i18ntool/internal/plural"
+)
+
+// GetPlural returns the i18next-style plural form of key for the count n:
+// key_one, key_few, ... as selected by the CLDR rules of lang (English when
+// unknown), falling back to key_other and then to key itself. n is available
+// to templates as .Count and .count unless data sets them. LanguageFromPath
+// derives lang from the file t was loaded from.
+func GetPlural(t Translations, lang, key string, n interface{}, data map[string]interface{}) (string, error) {
+	form, ok := pluralForm(t, lang, key, n)
+	if !ok {
+		return "", fmt.Errorf("no plural form of %q", key)
+	}
+	return GetTranslation(t, form, pluralData(n, data), "")
+}
+
+// pluralForm returns the key of the form GetPlural renders for n in lang.
+func pluralForm(t Translations, lang, key string, n interface{}) (string, bool) {
+	rules, ok := plural.For(lang)
+	if !ok {
+		rules, _ = plural.For("en")
+	}
+	for _, k := range []string{key + "_" + rules.Select(n), key + "_other", key} {
+		if _, ok := lookup(t, k).(string); ok {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// pluralData copies data and adds n as Count and count unless already set.
+func pluralData(n interface{}, data map[string]interface{}) map[string]interface{} {
+	vars := make(map[string]interface{}, len(data)+2)
+	for k, v := range data {
+		vars[k] = v
+	}
+	for _, name := range []string{"Count", "count"} {
+		if _, ok := vars[name]; !ok {
+			vars[name] = n
+		}
+	}
+	return vars
+}
@@ -7,13 +7,17 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"text/template"
 )
 
+//go:generate go run ../../cmd/i18n-manager embed --out embedded_translations.go --var EmbeddedTranslations ../../examples/locales/[a-z][a-z].json
+
 // Translations maps keys to string or nested maps.
 type Translations map[string]interface{}
 
-// LoadTranslations loads a JSON translation file from disk.
+// LoadTranslations loads a JSON translation file from disk. It is lenient: a
+// key repeated within an object silently keeps its last value, and a file
+// that cannot be read or parsed falls back to the embedded translations of
+// its language. LoadTranslationsStrict reports both instead.
 func LoadTranslations(filename string) (Translations, error) {
 	f, err := os.Open(filename)
 	if err == nil {
@@ -25,9 +29,13 @@ func LoadTranslations(filename string) (Translations, error) {
 		// if decode failed, fallthrough to embedded fallback
 	}
 
-	// attempt to detect language code from filename and use embedded translations
-	base := filepath.Base(filename)
-	name := strings.TrimSuffix(base, filepath.Ext(base))
+	// attempt to detect the language from the path (de.json, de/common.json)
+	// and use the embedded translations
+	name := LanguageFromPath(filename)
+	if name == "" {
+		base := filepath.Base(filename)
+		name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
 	if m, ok := EmbeddedTranslations[name]; ok {
 		return buildTranslationsFromFlat(m), nil
 	}
@@ -68,23 +76,43 @@ func buildTranslationsFromFlat(flat map[string]string) Translations {
 	return out
 }
 
-// GetTranslation returns the string for key or the fallback if missing.
-func GetTranslation(t Translations, key string, data map[string]interface{}, fallback string) (string, error) {
-	parts := splitKey(key)
-	var cur interface{} = t
-	for _, p := range parts {
-		switch m := cur.(type) {
-		case map[string]interface{}:
-			cur = m[p]
-		case Translations:
-			cur = mapInterface(m)[p]
-		default:
-			cur = nil
+// lookup follows a dotted key through nested maps to a string; it returns
+// nil if missing. Keys that contain dots in the file ({"a.b": ...}) match
+// several parts at once, but as in Catalog a path through nested objects
+// wins.
+func lookup(t Translations, key string) interface{} {
+	return lookupParts(t, splitKey(key))
+}
+
+func lookupParts(cur interface{}, parts []string) interface{} {
+	if len(parts) == 0 {
+		if s, ok := cur.(string); ok {
+			return s
 		}
-		if cur == nil {
-			break
+		return nil
+	}
+	var m map[string]interface{}
+	switch v := cur.(type) {
+	case map[string]interface{}:
+		m = v
+	case Translations:
+		m = v
+	default:
+		return nil
+	}
+	for i := 1; i <= len(parts); i++ {
+		if next, ok := m[strings.Join(parts[:i], ".")]; ok {
+			if s := lookupParts(next, parts[i:]); s != nil {
+				return s
+			}
 		}
 	}
+	return nil
+}
+
+// GetTranslation returns the string for key or the fallback if missing.
+func GetTranslation(t Translations, key string, data map[string]interface{}, fallback string) (string, error) {
+	cur := lookup(t, key)
 	if cur == nil {
 		return fallback, nil
 	}
@@ -105,54 +133,30 @@ func GetTranslation(t Translations, key string, data map[string]interface{}, fal
 	return fallback, nil
 }
 
-// render executes a text/template using data map.
+// render executes a text/template using data map. Parsed templates are
+// cached, see maxCached.
 func render(tmpl string, data map[string]interface{}) (string, error) {
-	t, err := template.New("msg").Parse(tmpl)
+	t, err := templates.get(tmpl)
 	if err != nil {
 		return "", err
 	}
-	var out string
-	if err := t.Execute(&stringWriter{&out}, data); err != nil {
+	var out strings.Builder
+	if err := t.Execute(&out, data); err != nil {
 		return "", err
 	}
-	return out, nil
-}
-
-// stringWriter appends bytes to a string.
-type stringWriter struct{ s *string }
-
-func (w *stringWriter) Write(p []byte) (int, error) {
-	*w.s += string(p)
-	return len(p), nil
+	return out.String(), nil
 }
 
-// splitKey splits dotted keys.
+// splitKey splits dotted keys, dropping empty segments.
 func splitKey(k string) []string {
-	var parts []string
-	cur := ""
-	for i := 0; i < len(k); i++ {
-		if k[i] == '.' {
-			if cur != "" {
-				parts = append(parts, cur)
-				cur = ""
-			}
-			continue
+	parts := strings.Split(k, ".")
+	out := parts[:0]
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
 		}
-		cur += string(k[i])
 	}
-	if cur != "" {
-		parts = append(parts, cur)
-	}
-	return parts
-}
-
-// mapInterface converts Translations to map[string]interface{}.
-func mapInterface(t Translations) map[string]interface{} {
-	m := make(map[string]interface{}, len(t))
-	for k, v := range t {
-		m[k] = v
-	}
-	return m
+	return out
 }
 
 // unescapeCommon converts sequences like "\n" into real newlines.
@@ -1,8 +1,10 @@
 package simpletrans
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -46,3 +48,95 @@ func TestGetTranslation_Unescape(t *testing.T) {
 		t.Fatalf("expected unescaped newline, got %q", out)
 	}
 }
+
+func TestLoadTranslationsStrict_DuplicateKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.json")
+	content := "{\n  \"common\": {\n    \"save\": \"Speichern\",\n    \"cancel\": \"Abbrechen\",\n    \"save\": \"Sichern\"\n  }\n}\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadTranslationsStrict(path)
+	dup, ok := err.(*DuplicateKeyError)
+	if !ok {
+		t.Fatalf("expected *DuplicateKeyError, got %v", err)
+	}
+	if dup.Key != "common.save" || dup.Line != 5 || dup.Column != 5 || dup.FirstLine != 3 || dup.FirstColumn != 5 {
+		t.Fatalf("unexpected duplicate: %+v", dup)
+	}
+}
+
+func TestCheckDuplicateKeys(t *testing.T) {
+	valid := []string{
+		`{"a": "x", "b": {"a": "y"}}`,
+		`{"list": [{"a": 1}, {"a": 2}], "a": "z"}`,
+		`{"esc\"aped": "x", "other": "y"}`,
+	}
+	for _, s := range valid {
+		if err := CheckDuplicateKeys([]byte(s)); err != nil {
+			t.Errorf("%s: unexpected error %v", s, err)
+		}
+	}
+	err := CheckDuplicateKeys([]byte(`{"a": [1, 2], "b": "x", "a": "y"}`))
+	if dup, ok := err.(*DuplicateKeyError); !ok || dup.Key != "a" || dup.Column != 25 || dup.FirstColumn != 2 {
+		t.Fatalf("unexpected result %+v", err)
+	}
+	if err := CheckDuplicateKeys([]byte(`{"a": }`)); err == nil {
+		t.Fatal("expected syntax error")
+	}
+
+	dups, err := FindDuplicateKeys([]byte(`{"a": 1, "b": {"c": 1, "c": 2}, "a": 2, "a": 3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, d := range dups {
+		keys = append(keys, fmt.Sprintf("%s@%d<-%d", d.Key, d.Column, d.FirstColumn))
+	}
+	if got, want := strings.Join(keys, " "), "b.c@24<-16 a@33<-2 a@41<-2"; got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+}
+
+func TestGetPlural(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pl.json")
+	content := `{"files": {"count_one": "{{.Count}} plik", "count_few": "{{.Count}} pliki", "count_many": "{{.Count}} plików", "count_other": "{{.Count}} pliku"}}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tr, err := LoadTranslations(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n, want := range map[interface{}]string{1: "1 plik", 3: "3 pliki", 12: "12 plików", "1.5": "1.5 pliku"} {
+		got, err := GetPlural(tr, "pl", "files.count", n, nil)
+		if err != nil || got != want {
+			t.Errorf("%v: want %q, got %q (%v)", n, want, got, err)
+		}
+	}
+
+	// without a known language English rules apply; missing forms fall back to _other
+	en := Translations{"item_other": "{{.Count}} items"}
+	if got, _ := GetPlural(en, "", "item", 1, nil); got != "1 items" {
+		t.Fatalf("expected _other fallback, got %q", got)
+	}
+	if _, err := GetPlural(en, "", "missing", 1, nil); err == nil {
+		t.Fatal("expected error for missing key")
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	tr := Translations{
+		"inbox":  map[string]interface{}{"count": "{n, plural, one {# wiadomość} few {# wiadomości} many {# wiadomości} other {# wiadomości}} od {name}"},
+		"broken": "{n, plural, one {x}}",
+	}
+	got, err := FormatMessage(tr, "pl", "inbox.count", map[string]interface{}{"n": 1, "name": "Ann"})
+	if err != nil || got != "1 wiadomość od Ann" {
+		t.Fatalf("unexpected result %q (%v)", got, err)
+	}
+	if _, err := FormatMessage(tr, "pl", "broken", map[string]interface{}{"n": 1}); err == nil {
+		t.Fatal("expected syntax error")
+	}
+	if _, err := FormatMessage(tr, "pl", "missing", nil); err == nil {
+		t.Fatal("expected error for missing key")
+	}
+}
@@ -0,0 +1,162 @@
+package simpletrans
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// DuplicateKeyError reports an object key that occurs twice in a translation
+// file. Line and Column locate the repeated key, FirstLine and FirstColumn the
+// original one; both are 1-based.
+type DuplicateKeyError struct {
+	File        string
+	Key         string
+	Line        int
+	Column      int
+	FirstLine   int
+	FirstColumn int
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: duplicate key %q (first defined at line %d, column %d)",
+		e.File, e.Line, e.Column, e.Key, e.FirstLine, e.FirstColumn)
+}
+
+// objectFrame tracks the keys seen in one open JSON object or array.
+type objectFrame struct {
+	object    bool
+	prefix    string
+	keys      map[string]int // key -> offset of its first occurrence
+	expectKey bool
+}
+
+// CheckDuplicateKeys returns a *DuplicateKeyError for the first key repeated
+// within the same object. Syntax errors are returned as reported by
+// encoding/json.
+func CheckDuplicateKeys(data []byte) error {
+	dups, err := FindDuplicateKeys(data)
+	if err != nil {
+		return err
+	}
+	if len(dups) > 0 {
+		return dups[0]
+	}
+	return nil
+}
+
+// FindDuplicateKeys walks data token by token and returns every key repeated
+// within the same object in file order, each pointing back to the first
+// occurrence. Syntax errors are returned as reported by encoding/json.
+func FindDuplicateKeys(data []byte) ([]*DuplicateKeyError, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var stack []*objectFrame
+	var dups []*DuplicateKeyError
+	pending := ""
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return dups, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var top *objectFrame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if top != nil && top.object && top.expectKey {
+			key, _ := tok.(string)
+			end := int(dec.InputOffset())
+			start := keyStart(data, end)
+			path := key
+			if top.prefix != "" {
+				path = top.prefix + "." + key
+			}
+			if first, ok := top.keys[key]; ok {
+				e := &DuplicateKeyError{Key: path}
+				e.Line, e.Column = position(data, start)
+				e.FirstLine, e.FirstColumn = position(data, first)
+				dups = append(dups, e)
+			} else {
+				top.keys[key] = start
+			}
+			top.expectKey = false
+			pending = path
+			continue
+		}
+
+		prefix := ""
+		if top != nil {
+			prefix = top.prefix
+			if top.object {
+				prefix = pending
+				top.expectKey = true
+			}
+		}
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, &objectFrame{object: true, prefix: prefix, keys: make(map[string]int), expectKey: true})
+		case json.Delim('['):
+			stack = append(stack, &objectFrame{prefix: prefix})
+		}
+	}
+}
+
+// keyStart returns the offset of the opening quote of the string token that
+// ends at end.
+func keyStart(data []byte, end int) int {
+	for i := end - 2; i >= 0; i-- {
+		if data[i] != '"' {
+			continue
+		}
+		backslashes := 0
+		for j := i - 1; j >= 0 && data[j] == '\\'; j-- {
+			backslashes++
+		}
+		if backslashes%2 == 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// position converts a byte offset into a 1-based line and column.
+func position(data []byte, offset int) (int, int) {
+	line := 1 + bytes.Count(data[:offset], []byte("\n"))
+	return line, offset - bytes.LastIndexByte(data[:offset], '\n')
+}
+
+// LoadTranslationsStrict loads a JSON translation file like LoadTranslations
+// but fails on duplicate keys and malformed JSON instead of silently keeping
+// the last value or falling back to the embedded translations.
+func LoadTranslationsStrict(filename string) (Translations, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", filename, err)
+	}
+	return decodeStrict(filename, data)
+}
+
+// decodeStrict decodes a translation file, rejecting duplicate keys.
+func decodeStrict(filename string, data []byte) (Translations, error) {
+	if err := CheckDuplicateKeys(data); err != nil {
+		if dup, ok := err.(*DuplicateKeyError); ok {
+			dup.File = filename
+			return nil, dup
+		}
+		return nil, fmt.Errorf("decode %s: %w", filename, err)
+	}
+	var t Translations
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", filename, err)
+	}
+	return t, nil
+}